@@ -0,0 +1,254 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"aoc/cmdline"
+)
+
+const programName = "aoc"
+
+// Exit codes shared by every command
+const (
+	exitOK = 0
+	// The command ran but failed, e.g. a wrong answer or a failing check
+	exitFailure = 1
+	// The command line itself was wrong
+	exitUsage = 2
+)
+
+// Command is one of the CLI's subcommands
+type Command struct {
+	name string
+	// The positional args, as shown in the usage line
+	args    string
+	summary string
+	// Words completed for the first positional arg, if it is one of a fixed set
+	words []string
+	// Whether args after "--" are passed on, to the day being run
+	passthrough bool
+	// setup defines the command's flags, and returns the function running it
+	// once they have been parsed.
+	setup func(flags *flag.FlagSet) func(args, extra []string) error
+}
+
+// usageError is returned for a wrong command line, so the usage is shown and
+// the exit code is exitUsage.
+type usageError struct {
+	err error
+}
+
+func (u usageError) Error() string {
+	return u.err.Error()
+}
+
+func usagef(format string, args ...any) error {
+	return usageError{fmt.Errorf(format, args...)}
+}
+
+// commands is filled in by init, as help and completion need to see it
+var commands []Command
+
+func init() {
+	commands = []Command{
+		{"new", "<day>", "create a day from the template", nil, false, newCommand},
+		{"run", "<day> [-- day args...]", "run a day and print its answers", nil, true, runCommand},
+		{"test", "<day>", "check a day's answers against the sample's and the README's", nil, false, testCommand},
+		{"watch", "<day>", "rerun a day whenever its files change, comparing the answers", nil, false, watchCommand},
+		{"bench", "<day> [-- day args...]", "time how long a day takes", nil, true, benchCommand},
+		{"fetch", "<day>", "download a day's input", nil, false, fetchCommand},
+		{"submit", "<day> <part> [answer]", "submit an answer, by default the one the day prints", nil, false, submitCommand},
+		{"stats", "", "summarise the days of a year", nil, false, statsCommand},
+		{"describe", "<day>", "save the puzzle text as Markdown", nil, false, describeCommand},
+		{"samples", "<day>", "pull the examples and their answers out of the puzzle", nil, false, samplesCommand},
+		{"inputs", "lock|unlock [day...]", "encrypt or decrypt the inputs", []string{"lock", "unlock"}, false, inputsCommand},
+		{"config", "show", "print the settings in use", []string{"show"}, false, configCommand},
+		{"check", "", "check the days only import the standard library", nil, false, checkCommand},
+		{"completion", "bash|zsh|fish", "print a shell completion script", []string{"bash", "zsh", "fish"}, false, completionCommand},
+		{"help", "[command]", "show help for a command", nil, false, helpCommand},
+	}
+}
+
+func findCommand(name string) (Command, bool) {
+	for _, command := range commands {
+		if command.name == name {
+			return command, true
+		}
+	}
+
+	return Command{}, false
+}
+
+func (c Command) usageLine() string {
+	line := fmt.Sprintf("%s %s [flags]", programName, c.name)
+	if c.args != "" {
+		line += " " + c.args
+	}
+
+	return line
+}
+
+// newFlagSet sets up a command's flags, with the usage written to w
+func (c Command) newFlagSet(w io.Writer) (*flag.FlagSet, func(args, extra []string) error) {
+	flags := flag.NewFlagSet(c.name, flag.ContinueOnError)
+	flags.SetOutput(w)
+
+	run := c.setup(flags)
+
+	flags.Usage = func() {
+		fmt.Fprintf(w, "Usage: %s\n\n%s\n", c.usageLine(), capitalise(c.summary))
+
+		hasFlags := false
+		flags.VisitAll(func(*flag.Flag) { hasFlags = true })
+
+		if hasFlags {
+			fmt.Fprintln(w, "\nFlags:")
+			flags.PrintDefaults()
+		}
+	}
+
+	return flags, run
+}
+
+func capitalise(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToUpper(s[:1]) + s[1:] + "."
+}
+
+// execute runs a command, returning the exit code
+func (c Command) execute(args []string) int {
+	flags, run := c.newFlagSet(os.Stderr)
+
+	extra := make([]string, 0)
+	if idx := indexOf(args, "--"); idx != -1 {
+		args, extra = args[:idx], args[idx+1:]
+	}
+
+	positional, err := cmdline.Parse(flags, args)
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	} else if err != nil {
+		// The flag package has already explained what was wrong
+		return exitUsage
+	}
+
+	if len(extra) > 0 && !c.passthrough {
+		err = usagef("%s doesn't take args after --", c.name)
+	} else {
+		err = run(positional, extra)
+	}
+
+	var usage usageError
+	if errors.As(err, &usage) {
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n\n", usage)
+		flags.Usage()
+
+		return exitUsage
+	} else if err != nil {
+		fmt.Printf("ERROR: %s\n", err.Error())
+
+		return exitFailure
+	}
+
+	return exitOK
+}
+
+func indexOf(args []string, value string) int {
+	for idx, arg := range args {
+		if arg == value {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+func parseDay(arg string) (int, error) {
+	day, err := strconv.Atoi(arg)
+	if err != nil || day < 1 || day > 25 {
+		return 0, usagef("invalid day: %q", arg)
+	}
+
+	return day, nil
+}
+
+// dayArg checks a command was given exactly one day, in a valid year
+func dayArg(year int, args []string) (int, error) {
+	if err := checkYear(year); err != nil {
+		return 0, usageError{err}
+	}
+
+	if len(args) != 1 {
+		return 0, usagef("expected exactly one day")
+	}
+
+	return parseDay(args[0])
+}
+
+func printCommands(w io.Writer) {
+	fmt.Fprintf(w, "Usage: %s <command> [flags] [args]\n\nCommands:\n", programName)
+
+	for _, command := range commands {
+		fmt.Fprintf(w, "  %-11s %s\n", command.name, command.summary)
+	}
+
+	fmt.Fprintf(w, "\nRun \"%s help <command>\" for a command's flags.\n", programName)
+}
+
+func helpCommand(flags *flag.FlagSet) func(args, extra []string) error {
+	return func(args, extra []string) error {
+		if len(args) == 0 {
+			printCommands(os.Stdout)
+			return nil
+		}
+
+		command, ok := findCommand(args[0])
+		if !ok || len(args) > 1 {
+			return usagef("unknown command: %q", strings.Join(args, " "))
+		}
+
+		flags, _ := command.newFlagSet(os.Stdout)
+		flags.Usage()
+
+		return nil
+	}
+}
+
+// runCLI picks the command from the args and runs it, returning the exit code
+func runCLI(args []string) int {
+	if len(args) == 0 {
+		printCommands(os.Stderr)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "-h", "-help", "--help":
+		printCommands(os.Stdout)
+		return exitOK
+	}
+
+	command, ok := findCommand(args[0])
+	if !ok {
+		// A bare day creates it, as the scaffolder always has
+		if _, err := strconv.Atoi(args[0]); err == nil {
+			command, _ = findCommand("new")
+			return command.execute(args)
+		}
+
+		fmt.Fprintf(os.Stderr, "ERROR: unknown command: %q\n\n", args[0])
+		printCommands(os.Stderr)
+
+		return exitUsage
+	}
+
+	return command.execute(args[1:])
+}
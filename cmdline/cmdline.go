@@ -0,0 +1,32 @@
+// Package cmdline parses command lines the same way for the CLI and the days.
+package cmdline
+
+import "flag"
+
+// Parse parses flags which may come before or after the positional args, and
+// returns the positional args. Everything after a "--" is positional.
+func Parse(flags *flag.FlagSet, args []string) ([]string, error) {
+	positional := make([]string, 0)
+
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+
+		rest := flags.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+
+		// The flag package stops after consuming a "--", so the rest are all
+		// positional rather than to be parsed again.
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+
+		args = rest
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
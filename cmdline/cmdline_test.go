@@ -0,0 +1,35 @@
+package cmdline
+
+import (
+	"flag"
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		args       []string
+		positional []string
+		verbose    bool
+	}{
+		{[]string{"-v", "a", "b"}, []string{"a", "b"}, true},
+		{[]string{"a", "-v", "b"}, []string{"a", "b"}, true},
+		{[]string{"--", "a", "-w"}, []string{"a", "-w"}, false},
+		{[]string{"a", "-v", "b", "--", "-w", "c"}, []string{"a", "b", "-w", "c"}, true},
+	}
+
+	for _, c := range cases {
+		flags := flag.NewFlagSet("test", flag.ContinueOnError)
+		verbose := flags.Bool("v", false, "")
+
+		positional, err := Parse(flags, c.args)
+		if err != nil {
+			t.Errorf("%q: %s", c.args, err)
+			continue
+		}
+
+		if !slices.Equal(positional, c.positional) || *verbose != c.verbose {
+			t.Errorf("%q: got %q and -v %t, expected %q and -v %t", c.args, positional, *verbose, c.positional, c.verbose)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// completionFlag is what the completion scripts need to know about a flag
+type completionFlag struct {
+	name, usage string
+	// Whether the flag is followed by a value
+	takesValue bool
+}
+
+func commandFlags(command Command) []completionFlag {
+	flags, _ := command.newFlagSet(io.Discard)
+	result := make([]completionFlag, 0)
+
+	flags.VisitAll(func(f *flag.Flag) {
+		isBool := false
+		if boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool }); ok {
+			isBool = boolFlag.IsBoolFlag()
+		}
+
+		result = append(result, completionFlag{f.Name, f.Usage, !isBool})
+	})
+
+	return result
+}
+
+// shellQuote wraps s in single quotes, for any of the shells
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func commandNames() []string {
+	names := make([]string, 0, len(commands))
+	for _, command := range commands {
+		names = append(names, command.name)
+	}
+
+	return names
+}
+
+func bashCompletion(w io.Writer) {
+	fmt.Fprintf(w, "# bash completion for %s\n", programName)
+	fmt.Fprintf(w, "_%s() {\n", programName)
+	fmt.Fprintln(w, `	local cur=${COMP_WORDS[COMP_CWORD]}`)
+	fmt.Fprintln(w, `	if [ "$COMP_CWORD" -eq 1 ]; then`)
+	fmt.Fprintf(w, "\t\tCOMPREPLY=($(compgen -W %s -- \"$cur\"))\n", shellQuote(strings.Join(commandNames(), " ")))
+	fmt.Fprintln(w, "\t\treturn")
+	fmt.Fprintln(w, "\tfi")
+	fmt.Fprintln(w, `	case ${COMP_WORDS[1]} in`)
+
+	for _, command := range commands {
+		words := make([]string, 0)
+		for _, f := range commandFlags(command) {
+			words = append(words, "--"+f.name)
+		}
+
+		words = append(words, command.words...)
+
+		if len(words) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(w, "\t%s) COMPREPLY=($(compgen -W %s -- \"$cur\")) ;;\n", command.name, shellQuote(strings.Join(words, " ")))
+	}
+
+	fmt.Fprintln(w, "\tesac")
+	fmt.Fprintln(w, "}")
+	fmt.Fprintf(w, "complete -F _%s %s\n", programName, programName)
+}
+
+// zshDescription swaps out the characters zsh treats specially in descriptions
+func zshDescription(s string) string {
+	return strings.NewReplacer("[", "(", "]", ")", ":", " -").Replace(s)
+}
+
+func zshCompletion(w io.Writer) {
+	fmt.Fprintf(w, "#compdef %s\n\n", programName)
+	fmt.Fprintf(w, "_%s() {\n", programName)
+	fmt.Fprintln(w, "\tlocal -a commands")
+	fmt.Fprintln(w, "\tcommands=(")
+
+	for _, command := range commands {
+		fmt.Fprintf(w, "\t\t%s\n", shellQuote(command.name+":"+zshDescription(command.summary)))
+	}
+
+	fmt.Fprintln(w, "\t)")
+	fmt.Fprintln(w, "\tif (( CURRENT == 2 )); then")
+	fmt.Fprintln(w, "\t\t_describe 'command' commands")
+	fmt.Fprintln(w, "\t\treturn")
+	fmt.Fprintln(w, "\tfi")
+	fmt.Fprintln(w, "\tcase $words[2] in")
+
+	for _, command := range commands {
+		specs := make([]string, 0)
+
+		for _, f := range commandFlags(command) {
+			spec := fmt.Sprintf("--%s[%s]", f.name, zshDescription(f.usage))
+			if f.takesValue {
+				spec += ":" + f.name + ":"
+			}
+
+			specs = append(specs, shellQuote(spec))
+		}
+
+		if len(command.words) > 0 {
+			specs = append(specs, shellQuote("1:"+command.name+":("+strings.Join(command.words, " ")+")"))
+		}
+
+		if len(specs) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(w, "\t%s) _arguments %s ;;\n", command.name, strings.Join(specs, " "))
+	}
+
+	fmt.Fprintln(w, "\tesac")
+	fmt.Fprintln(w, "}")
+	fmt.Fprintf(w, "\ncompdef _%s %s\n", programName, programName)
+}
+
+func fishCompletion(w io.Writer) {
+	fmt.Fprintf(w, "# fish completion for %s\n", programName)
+	fmt.Fprintf(w, "complete -c %s -f\n", programName)
+
+	for _, command := range commands {
+		fmt.Fprintf(w, "complete -c %s -n __fish_use_subcommand -a %s -d %s\n", programName, command.name, shellQuote(command.summary))
+	}
+
+	for _, command := range commands {
+		condition := shellQuote("__fish_seen_subcommand_from " + command.name)
+
+		for _, f := range commandFlags(command) {
+			line := fmt.Sprintf("complete -c %s -n %s -l %s -d %s", programName, condition, f.name, shellQuote(f.usage))
+			if f.takesValue {
+				line += " -r"
+			}
+
+			fmt.Fprintln(w, line)
+		}
+
+		if len(command.words) > 0 {
+			fmt.Fprintf(w, "complete -c %s -n %s -a %s\n", programName, condition, shellQuote(strings.Join(command.words, " ")))
+		}
+	}
+}
+
+func completionCommand(flags *flag.FlagSet) func(args, extra []string) error {
+	return func(args, extra []string) error {
+		if len(args) != 1 {
+			return usagef("expected one of bash, zsh or fish")
+		}
+
+		switch args[0] {
+		case "bash":
+			bashCompletion(os.Stdout)
+		case "zsh":
+			zshCompletion(os.Stdout)
+		case "fish":
+			fishCompletion(os.Stdout)
+		default:
+			return usagef("unsupported shell: %q", args[0])
+		}
+
+		return nil
+	}
+}
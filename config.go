@@ -0,0 +1,357 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"aoc/inputs"
+)
+
+const configName = ".aoc.toml"
+
+// Config holds the settings shared by every command. It is read from
+// .aoc.toml in the repo, or aoc/config.toml in the user's config directory,
+// and any AOC_* environment variable overrides the file.
+type Config struct {
+	// File holding the session cookie, only read when AOC_SESSION isn't set
+	SessionFile string
+	Year        int
+	// Where a year's days go, with {year} replaced by the year
+	DaysDir  string
+	Template string
+	// Whether days run against "input" or "sample" by default
+	Input string
+	// File holding the key inputs are encrypted with, empty for the default
+	InputKeyFile string
+
+	// Where each setting came from, for config show
+	sources map[string]string
+}
+
+var defaultConfig = Config{
+	SessionFile: "",
+	Year:        2024,
+	DaysDir:     "years/{year}/days",
+	Template:    "template/template.go",
+	Input:       "input",
+}
+
+// config is used by every command, main replaces it with loadConfig
+var config = defaultConfig
+
+// configSetting ties a key in the file, and the environment variable that
+// overrides it, to a field.
+type configSetting struct {
+	key, env string
+	field    func(c *Config) any
+}
+
+var configSettings = []configSetting{
+	{"session_file", "AOC_SESSION_FILE", func(c *Config) any { return &c.SessionFile }},
+	{"year", "AOC_YEAR", func(c *Config) any { return &c.Year }},
+	{"days_dir", "AOC_DAYS_DIR", func(c *Config) any { return &c.DaysDir }},
+	{"template", "AOC_TEMPLATE", func(c *Config) any { return &c.Template }},
+	{"run.input", "AOC_INPUT", func(c *Config) any { return &c.Input }},
+	{"input_key_file", "AOC_INPUT_KEY_FILE", func(c *Config) any { return &c.InputKeyFile }},
+}
+
+func (c *Config) set(setting configSetting, value, source string) error {
+	switch field := setting.field(c).(type) {
+	case *string:
+		*field = value
+	case *int:
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s: %s must be a number, got %q", source, setting.key, value)
+		}
+
+		*field = parsed
+	}
+
+	c.sources[setting.key] = source
+
+	return nil
+}
+
+// configPaths lists where the config file is looked for, in order
+func configPaths() []string {
+	paths := []string{configName}
+
+	if dir, err := os.UserConfigDir(); err == nil {
+		paths = append(paths, filepath.Join(dir, "aoc", "config.toml"))
+	}
+
+	return paths
+}
+
+// parseTOML reads the small part of TOML the config needs, "key = value"
+// lines with string or number values, and [section] headers. Keys in a
+// section are returned as "section.key".
+func parseTOML(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	values := make(map[string]string)
+	section := ""
+	lineNumber := 0
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1:len(line)-1]) + "."
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected key = value", path, lineNumber)
+		}
+
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		if strings.HasPrefix(value, `"`) {
+			unquoted, rest, err := unquotePrefix(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, lineNumber, err)
+			}
+
+			if rest = strings.TrimSpace(rest); rest != "" && !strings.HasPrefix(rest, "#") {
+				return nil, fmt.Errorf("%s:%d: unexpected %q after value", path, lineNumber, rest)
+			}
+
+			value = unquoted
+		} else if idx := strings.Index(value, "#"); idx != -1 {
+			value = strings.TrimSpace(value[:idx])
+		}
+
+		values[section+key] = value
+	}
+
+	return values, scanner.Err()
+}
+
+// unquotePrefix unquotes the basic string at the start of s, returning
+// whatever follows it.
+func unquotePrefix(s string) (string, string, error) {
+	for end := 1; end < len(s); end++ {
+		if s[end] == '\\' {
+			end++
+			continue
+		}
+
+		if s[end] == '"' {
+			unquoted, err := strconv.Unquote(s[:end+1])
+			return unquoted, s[end+1:], err
+		}
+	}
+
+	return "", "", fmt.Errorf("unterminated string %s", s)
+}
+
+// loadConfig starts from the defaults, applies the first config file found
+// and then the environment.
+func loadConfig() (Config, error) {
+	c := defaultConfig
+	c.sources = make(map[string]string)
+
+	for _, path := range configPaths() {
+		values, err := parseTOML(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return Config{}, err
+		}
+
+		for _, setting := range configSettings {
+			if value, ok := values[setting.key]; ok {
+				if err := c.set(setting, value, path); err != nil {
+					return Config{}, err
+				}
+
+				delete(values, setting.key)
+			}
+		}
+
+		for key := range values {
+			return Config{}, fmt.Errorf("%s: unknown setting %q", path, key)
+		}
+
+		break
+	}
+
+	for _, setting := range configSettings {
+		if value, ok := os.LookupEnv(setting.env); ok {
+			if err := c.set(setting, value, setting.env); err != nil {
+				return Config{}, err
+			}
+		}
+	}
+
+	if err := checkYear(c.Year); err != nil {
+		return Config{}, err
+	}
+
+	// The days only see the environment, so they need telling where the key is
+	if c.InputKeyFile != "" {
+		path, err := expandHome(c.InputKeyFile)
+		if err != nil {
+			return Config{}, err
+		}
+
+		if err := os.Setenv("AOC_INPUT_KEY_FILE", path); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if c.Input != "input" && c.Input != "sample" {
+		return Config{}, fmt.Errorf("run.input must be input or sample, got %q", c.Input)
+	}
+
+	return c, nil
+}
+
+// session returns the session cookie, from AOC_SESSION or the session file.
+// It is empty when neither is set.
+func (c Config) session() (string, error) {
+	if session := os.Getenv("AOC_SESSION"); session != "" {
+		return session, nil
+	}
+
+	if c.SessionFile == "" {
+		return "", nil
+	}
+
+	path, err := expandHome(c.SessionFile)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// expandHome replaces a leading ~/ with the user's home directory
+func expandHome(path string) (string, error) {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, rest), nil
+}
+
+// redact hides a secret, only saying whether it is set
+func redact(secret string) string {
+	if secret == "" {
+		return "(not set)"
+	}
+
+	return fmt.Sprintf("(redacted, %d characters)", len(secret))
+}
+
+func configCommand(flags *flag.FlagSet) func(args, extra []string) error {
+	return func(args, extra []string) error {
+		if len(args) != 1 || args[0] != "show" {
+			return usagef("expected show")
+		}
+
+		return showConfig()
+	}
+}
+
+// showConfig prints every setting and where it came from, with the session
+// itself redacted.
+func showConfig() error {
+	source := func(key string) string {
+		if source, ok := config.sources[key]; ok {
+			return source
+		}
+
+		return "default"
+	}
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+
+	for _, setting := range configSettings {
+		fmt.Fprintf(table, "%s\t= %v\t# %s\n", setting.key, settingValue(setting.field(&config)), source(setting.key))
+	}
+
+	sessionSource := "default"
+	if os.Getenv("AOC_SESSION") != "" {
+		sessionSource = "AOC_SESSION"
+	} else if config.SessionFile != "" {
+		sessionSource = config.SessionFile
+	}
+
+	// A missing session file is reported rather than failing, as showing the
+	// settings is how to find out it is missing.
+	session, err := config.session()
+	if errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(table, "%s\t= (not set, %s missing)\t# %s\n", "session", config.SessionFile, sessionSource)
+	} else if err != nil {
+		return err
+	} else {
+		fmt.Fprintf(table, "%s\t= %s\t# %s\n", "session", redact(session), sessionSource)
+	}
+
+	keySource := "AOC_INPUT_KEY"
+	if os.Getenv("AOC_INPUT_KEY") == "" {
+		keySource, err = inputs.KeyFile()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Only whether the key is set, its length says nothing as keys are all the
+	// same size.
+	keyState := "(set)"
+	if _, err := inputs.Key(); errors.Is(err, inputs.ErrNoKey) {
+		keyState = "(not set)"
+	} else if errors.Is(err, inputs.ErrBadKey) {
+		keyState = "(set, but not 64 hex digits)"
+	} else if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(table, "%s\t= %s\t# %s\n", "input_key", keyState, keySource)
+
+	return table.Flush()
+}
+
+func settingValue(field any) any {
+	switch field := field.(type) {
+	case *string:
+		return strconv.Quote(*field)
+	case *int:
+		return *field
+	}
+
+	return field
+}
@@ -0,0 +1,424 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"aoc/inputs"
+)
+
+// sampleFlag adds --sample, which defaults to the run.input setting
+func sampleFlag(flags *flag.FlagSet) *bool {
+	return flags.Bool("sample", config.Input == "sample", "run against sample.txt instead of input.txt")
+}
+
+// dayArgs are the args a day is run with, it reads sample.txt when the first
+// one is "sample".
+func dayArgs(sample bool, extra []string) []string {
+	args := make([]string, 0, len(extra)+1)
+	if sample {
+		args = append(args, "sample")
+	}
+
+	return append(args, extra...)
+}
+
+// runDay runs a day with go run, writing what it prints to stdout
+func runDay(dir string, args []string, stdout io.Writer) error {
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err != nil {
+		return fmt.Errorf("no day in %s", dir)
+	}
+
+	cmd := exec.Command("go", append([]string{"run", "."}, args...)...)
+	cmd.Dir = dir
+	cmd.Stdout = stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("running %s: %w", dir, err)
+	}
+
+	return nil
+}
+
+// dayOutput runs a day and returns what it printed, which is also the error
+// when it fails, as the days print their errors rather than exit with them.
+func dayOutput(dir string, args []string) (string, error) {
+	var output bytes.Buffer
+
+	if err := runDay(dir, args, &output); err != nil {
+		if printed := strings.TrimSpace(output.String()); printed != "" {
+			return "", fmt.Errorf("%w: %s", err, printed)
+		}
+
+		return "", err
+	}
+
+	return output.String(), nil
+}
+
+var answerLinePattern = regexp.MustCompile(`(?m)^Part (\d+): (.*)$`)
+
+// parseAnswers picks the answers out of lines written the way the days print
+// them, "Part 1: 1234".
+func parseAnswers(output string) map[int]string {
+	answers := make(map[int]string)
+
+	for _, match := range answerLinePattern.FindAllStringSubmatch(output, -1) {
+		part, _ := strconv.Atoi(match[1])
+		answers[part] = strings.TrimSpace(match[2])
+	}
+
+	return answers
+}
+
+// readmeAnswer is a part's answer as recorded in the README's checklist
+type readmeAnswer struct {
+	value  string
+	solved bool
+}
+
+var (
+	readmeYearPattern   = regexp.MustCompile(`^###\s+(\d{4})\s*$`)
+	readmeDayPattern    = regexp.MustCompile(`^#+\s+Day\s+(\d+)\s*$`)
+	readmeAnswerPattern = regexp.MustCompile(`^-\s+\[([ xX])\]\s+Part\s+(\d+):\s*(.*)$`)
+)
+
+// readmeAnswers reads the answers listed in the README for a year, by day
+// and then part.
+func readmeAnswers(year int) (map[int]map[int]readmeAnswer, error) {
+	file, err := os.Open("README.md")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	answers := make(map[int]map[int]readmeAnswer)
+	inYear := false
+	day := 0
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if match := readmeYearPattern.FindStringSubmatch(line); match != nil {
+			inYear = match[1] == strconv.Itoa(year)
+			day = 0
+		} else if match := readmeDayPattern.FindStringSubmatch(line); match != nil && inYear {
+			day, _ = strconv.Atoi(match[1])
+			answers[day] = make(map[int]readmeAnswer)
+		} else if match := readmeAnswerPattern.FindStringSubmatch(line); match != nil && inYear && day > 0 {
+			part, _ := strconv.Atoi(match[2])
+			answers[day][part] = readmeAnswer{strings.TrimSpace(match[3]), match[1] != " "}
+		}
+	}
+
+	return answers, scanner.Err()
+}
+
+func runCommand(flags *flag.FlagSet) func(args, extra []string) error {
+	year := yearFlag(flags)
+	sample := sampleFlag(flags)
+
+	return func(args, extra []string) error {
+		day, err := dayArg(*year, args)
+		if err != nil {
+			return err
+		}
+
+		return runDay(dayDir(*year, day), dayArgs(*sample, extra), os.Stdout)
+	}
+}
+
+// compareAnswers prints each answer next to the one expected, for every
+// part or just the one given, and returns the number that were wrong.
+func compareAnswers(name string, expected, actual map[int]string, only int) int {
+	parts := make([]int, 0, len(expected)+len(actual))
+	for part := range expected {
+		parts = append(parts, part)
+	}
+
+	for part := range actual {
+		if _, ok := expected[part]; !ok {
+			parts = append(parts, part)
+		}
+	}
+
+	slices.Sort(parts)
+
+	failures := 0
+
+	for _, part := range parts {
+		if only != 0 && part != only {
+			continue
+		}
+
+		want, ok := expected[part]
+
+		switch {
+		case !ok:
+			fmt.Printf("%s part %d: %s (nothing to check it against)\n", name, part, actual[part])
+		case actual[part] == want:
+			fmt.Printf("%s part %d: ok (%s)\n", name, part, actual[part])
+		default:
+			fmt.Printf("%s part %d: got %q, expected %q\n", name, part, actual[part], want)
+			failures++
+		}
+	}
+
+	return failures
+}
+
+// sampleAnswers reads the answers saved by the samples command, if any
+func sampleAnswers(dir string) (map[int]string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "sample.answers.txt"))
+	if errors.Is(err, os.ErrNotExist) {
+		return map[int]string{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return parseAnswers(string(data)), nil
+}
+
+// inputAnswers reads the parts of a day marked as solved in the README
+func inputAnswers(year, day int) (map[int]string, error) {
+	readme, err := readmeAnswers(year)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	expected := make(map[int]string)
+	for part, answer := range readme[day] {
+		if answer.solved {
+			expected[part] = answer.value
+		}
+	}
+
+	return expected, nil
+}
+
+// testDay runs a day against its sample, checking the answers saved by the
+// samples command, then against its input, checking the README's answers.
+// It returns the number of checks made and failed.
+func testDay(year, day int) (int, int, error) {
+	dir := dayDir(year, day)
+	checked, failed := 0, 0
+
+	expected, err := sampleAnswers(dir)
+	if err != nil {
+		return checked, failed, err
+	}
+
+	if len(expected) > 0 {
+		output, err := dayOutput(dir, dayArgs(true, nil))
+		if err != nil {
+			return checked, failed, err
+		}
+
+		checked += len(expected)
+		failed += compareAnswers("sample", expected, parseAnswers(output), 0)
+	}
+
+	expected, err = inputAnswers(year, day)
+	if err != nil {
+		return checked, failed, err
+	}
+
+	if len(expected) == 0 || !hasInput(dir) {
+		return checked, failed, nil
+	}
+
+	output, err := dayOutput(dir, dayArgs(false, nil))
+	if err != nil {
+		return checked, failed, err
+	}
+
+	checked += len(expected)
+	failed += compareAnswers("input", expected, parseAnswers(output), 0)
+
+	return checked, failed, nil
+}
+
+func testCommand(flags *flag.FlagSet) func(args, extra []string) error {
+	year := yearFlag(flags)
+
+	return func(args, extra []string) error {
+		day, err := dayArg(*year, args)
+		if err != nil {
+			return err
+		}
+
+		checked, failed, err := testDay(*year, day)
+		if err != nil {
+			return err
+		}
+
+		if checked == 0 {
+			return errors.New("nothing to check, there are no sample answers or solved parts in the README")
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d answer(s) wrong", failed, checked)
+		}
+
+		return nil
+	}
+}
+
+func benchCommand(flags *flag.FlagSet) func(args, extra []string) error {
+	year := yearFlag(flags)
+	sample := sampleFlag(flags)
+	count := flags.Int("count", 5, "how many times to run the day")
+
+	return func(args, extra []string) error {
+		day, err := dayArg(*year, args)
+		if err != nil {
+			return err
+		}
+
+		if *count < 1 {
+			return usagef("--count must be at least 1")
+		}
+
+		dir := dayDir(*year, day)
+
+		tmp, err := os.MkdirTemp("", "aoc-bench")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmp)
+
+		// Built once first, so compiling isn't part of the timings
+		binary := filepath.Join(tmp, "day")
+
+		build := exec.Command("go", "build", "-o", binary, ".")
+		build.Dir = dir
+		build.Stdout = os.Stdout
+		build.Stderr = os.Stderr
+
+		if err := build.Run(); err != nil {
+			return fmt.Errorf("building %s: %w", dir, err)
+		}
+
+		timings := make([]time.Duration, 0, *count)
+
+		for range *count {
+			cmd := exec.Command(binary, dayArgs(*sample, extra)...)
+			cmd.Dir = dir
+			cmd.Stderr = os.Stderr
+
+			start := time.Now()
+			if output, err := cmd.Output(); err != nil {
+				os.Stdout.Write(output)
+				return fmt.Errorf("running %s: %w", dir, err)
+			}
+
+			timings = append(timings, time.Since(start))
+		}
+
+		total := time.Duration(0)
+		for _, timing := range timings {
+			total += timing
+		}
+
+		fmt.Printf("%d run(s): min %s, mean %s, max %s\n", *count, slices.Min(timings), total/time.Duration(*count), slices.Max(timings))
+
+		return nil
+	}
+}
+
+// hasInput is true when a day has an input, whether plain or encrypted
+func hasInput(dir string) bool {
+	for _, name := range []string{inputName, inputName + inputs.Suffix} {
+		if info, err := os.Stat(filepath.Join(dir, name)); err == nil && info.Size() > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func inputState(dir string) string {
+	if info, err := os.Stat(filepath.Join(dir, inputName+inputs.Suffix)); err == nil && info.Size() > 0 {
+		return "locked"
+	}
+
+	if hasInput(dir) {
+		return "plain"
+	}
+
+	return "-"
+}
+
+func fileState(dir, name string) string {
+	if info, err := os.Stat(filepath.Join(dir, name)); err == nil && info.Size() > 0 {
+		return "yes"
+	}
+
+	return "-"
+}
+
+func statsCommand(flags *flag.FlagSet) func(args, extra []string) error {
+	year := yearFlag(flags)
+
+	return func(args, extra []string) error {
+		if len(args) > 0 {
+			return usagef("stats doesn't take any args")
+		}
+
+		if err := checkYear(*year); err != nil {
+			return usageError{err}
+		}
+
+		readme, err := readmeAnswers(*year)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, "day\tinput\tsample\tpuzzle\tstars")
+
+		days, stars := 0, 0
+
+		for day := 1; day <= 25; day++ {
+			dir := dayDir(*year, day)
+			if _, err := os.Stat(dir); err != nil {
+				continue
+			}
+
+			dayStars := 0
+			for _, answer := range readme[day] {
+				if answer.solved {
+					dayStars++
+				}
+			}
+
+			days++
+			stars += dayStars
+
+			fmt.Fprintf(table, "%d\t%s\t%s\t%s\t%s\n", day, inputState(dir), fileState(dir, "sample.txt"), fileState(dir, "PUZZLE.md"), strings.Repeat("*", dayStars))
+		}
+
+		if err := table.Flush(); err != nil {
+			return err
+		}
+
+		fmt.Printf("\n%d: %d day(s), %d star(s)\n", *year, days, stars)
+
+		return nil
+	}
+}
@@ -1,3 +0,0 @@
-module d1
-
-go 1.23.0
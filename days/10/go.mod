@@ -1,3 +0,0 @@
-module d10
-
-go 1.23.0
@@ -1,3 +0,0 @@
-module d11
-
-go 1.23.0
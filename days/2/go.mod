@@ -1,3 +0,0 @@
-module d2
-
-go 1.23.0
@@ -1,5 +0,0 @@
-module d3
-
-go 1.23.0
-
-require github.com/dlclark/regexp2 v1.11.4
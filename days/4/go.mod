@@ -1,3 +0,0 @@
-module d4
-
-go 1.23.0
@@ -1,3 +0,0 @@
-module d5
-
-go 1.23.0
@@ -1,3 +0,0 @@
-module d6
-
-go 1.23.0
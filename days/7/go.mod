@@ -1,3 +0,0 @@
-module d7
-
-go 1.23.0
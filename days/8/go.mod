@@ -1,3 +0,0 @@
-module d8
-
-go 1.23.0
@@ -1,3 +0,0 @@
-module d9
-
-go 1.23.0
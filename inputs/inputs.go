@@ -0,0 +1,185 @@
+// Package inputs keeps puzzle inputs encrypted at rest, so they can be
+// committed without publishing them, and reads them back transparently.
+package inputs
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Suffix is added to an input's name when it is encrypted
+const Suffix = ".enc"
+
+// Written at the start of every encrypted file, so anything else is rejected
+// before trying to decrypt it.
+var magic = []byte("AOCENC1\n")
+
+// ErrNoKey is returned when an input is encrypted but there is no key to
+// decrypt it with.
+var ErrNoKey = errors.New("input is encrypted but no key was found, set AOC_INPUT_KEY or AOC_INPUT_KEY_FILE")
+
+// DefaultKeyFile is where the key is kept unless AOC_INPUT_KEY_FILE says
+// otherwise, under the user's config directory.
+func DefaultKeyFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "aoc", "input.key"), nil
+}
+
+// KeyFile is the file the key is read from
+func KeyFile() (string, error) {
+	if path := os.Getenv("AOC_INPUT_KEY_FILE"); path != "" {
+		return path, nil
+	}
+
+	return DefaultKeyFile()
+}
+
+// ErrBadKey is returned for a key that isn't one made by NewKey. Passphrases
+// aren't accepted, as the encrypted inputs are public and a passphrase could be
+// guessed offline.
+var ErrBadKey = errors.New("the key must be 64 hex digits, like the one made by the first lock")
+
+// ParseKey turns the text of a key, 64 hex digits, into an AES-256 key
+func ParseKey(text string) ([]byte, error) {
+	key, err := hex.DecodeString(strings.TrimSpace(text))
+	if err != nil || len(key) != 32 {
+		return nil, ErrBadKey
+	}
+
+	return key, nil
+}
+
+// Key reads the key from AOC_INPUT_KEY, or from the key file. It returns
+// ErrNoKey when neither is set.
+func Key() ([]byte, error) {
+	if text := os.Getenv("AOC_INPUT_KEY"); text != "" {
+		key, err := ParseKey(text)
+		if err != nil {
+			return nil, fmt.Errorf("AOC_INPUT_KEY: %w", err)
+		}
+
+		return key, nil
+	}
+
+	path, err := KeyFile()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoKey
+	} else if err != nil {
+		return nil, err
+	}
+
+	key, err := ParseKey(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return key, nil
+}
+
+// NewKey returns a random key, written as hex
+func NewKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(key), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Encrypt seals the plaintext with AES-GCM under a fresh random nonce
+func Encrypt(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	result := append([]byte{}, magic...)
+	result = append(result, nonce...)
+
+	return gcm.Seal(result, nonce, plaintext, nil), nil
+}
+
+// Decrypt opens data written by Encrypt, failing if it was made with another
+// key or has been changed since.
+func Decrypt(key, data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, magic) {
+		return nil, errors.New("not an encrypted input")
+	}
+
+	data = data[len(magic):]
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("encrypted input is truncated")
+	}
+
+	plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.New("could not decrypt input, the key is wrong or the file is damaged")
+	}
+
+	return plaintext, nil
+}
+
+// ReadFile reads the named file, or decrypts name+Suffix when only the
+// encrypted copy is there.
+func ReadFile(name string) ([]byte, error) {
+	data, err := os.ReadFile(name)
+	if !errors.Is(err, os.ErrNotExist) {
+		return data, err
+	}
+
+	encrypted, encErr := os.ReadFile(name + Suffix)
+	if errors.Is(encErr, os.ErrNotExist) {
+		return nil, err
+	} else if encErr != nil {
+		return nil, encErr
+	}
+
+	key, err := Key()
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := Decrypt(key, encrypted)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name+Suffix, err)
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"aoc/inputs"
+)
+
+const inputName = "input.txt"
+
+// inputKey reads the key inputs are encrypted with. When locking and there is
+// no key yet a new one is made, and saved to the key file.
+func inputKey(create bool) ([]byte, error) {
+	key, err := inputs.Key()
+	if !errors.Is(err, inputs.ErrNoKey) || !create {
+		return key, err
+	}
+
+	path, err := inputs.KeyFile()
+	if err != nil {
+		return nil, err
+	}
+
+	text, err := inputs.NewKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+
+	// O_EXCL so an existing key is never overwritten
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := fmt.Fprintln(file, text); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("created a new key in %s, keep a copy of it somewhere safe\n", path)
+
+	return inputs.ParseKey(text)
+}
+
+// lockedCopyMatches is true when the encrypted input already holds plaintext.
+// Each encryption uses a new nonce, so rewriting an unchanged input would
+// still change the file.
+func lockedCopyMatches(path string, key, plaintext []byte) bool {
+	encrypted, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	existing, err := inputs.Decrypt(key, encrypted)
+
+	return err == nil && bytes.Equal(existing, plaintext)
+}
+
+// lockInput encrypts a day's input next to it, and removes the plain copy
+// unless asked to keep it.
+func lockInput(dir string, key []byte, keep bool) (bool, error) {
+	path := filepath.Join(dir, inputName)
+
+	plaintext, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && len(plaintext) == 0) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	if !lockedCopyMatches(path+inputs.Suffix, key, plaintext) {
+		encrypted, err := inputs.Encrypt(key, plaintext)
+		if err != nil {
+			return false, err
+		}
+
+		if err := os.WriteFile(path+inputs.Suffix, encrypted, 0600); err != nil {
+			return false, err
+		}
+	}
+
+	if keep {
+		return true, nil
+	}
+
+	return true, os.Remove(path)
+}
+
+// unlockInput writes a day's encrypted input back out as plain text
+func unlockInput(dir string, key []byte) (bool, error) {
+	path := filepath.Join(dir, inputName)
+
+	encrypted, err := os.ReadFile(path + inputs.Suffix)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	plaintext, err := inputs.Decrypt(key, encrypted)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", path+inputs.Suffix, err)
+	}
+
+	return true, os.WriteFile(path, plaintext, 0600)
+}
+
+// inputDirs returns the directories for the given days, or every day in the
+// year when none are given.
+func inputDirs(year int, days []string) ([]string, error) {
+	if len(days) == 0 {
+		dirs, err := filepath.Glob(filepath.Join(filepath.Dir(dayDir(year, 1)), "*"))
+		if err != nil {
+			return nil, err
+		}
+
+		return dirs, nil
+	}
+
+	dirs := make([]string, 0, len(days))
+
+	for _, day := range days {
+		number, err := parseDay(day)
+		if err != nil {
+			return nil, err
+		}
+
+		dirs = append(dirs, dayDir(year, number))
+	}
+
+	return dirs, nil
+}
+
+// inputsCommand handles "inputs lock" and "inputs unlock", for the given
+// days or every day in the year.
+func inputsCommand(flags *flag.FlagSet) func(args, extra []string) error {
+	year := yearFlag(flags)
+	keep := flags.Bool("keep", false, "with lock, keep the plain input.txt as well")
+
+	return func(args, extra []string) error {
+		if len(args) == 0 || (args[0] != "lock" && args[0] != "unlock") {
+			return usagef("expected lock or unlock")
+		}
+
+		if err := checkYear(*year); err != nil {
+			return usageError{err}
+		}
+
+		lock := args[0] == "lock"
+
+		dirs, err := inputDirs(*year, args[1:])
+		if err != nil {
+			return err
+		}
+
+		key, err := inputKey(lock)
+		if err != nil {
+			return err
+		}
+
+		for _, dir := range dirs {
+			var done bool
+			if lock {
+				done, err = lockInput(dir, key, *keep)
+			} else {
+				done, err = unlockInput(dir, key)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if done {
+				fmt.Printf("%sed %s\n", args[0], filepath.Join(dir, inputName))
+			}
+		}
+
+		return nil
+	}
+}
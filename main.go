@@ -1,72 +1,274 @@
 package main
 
 import (
+	"bufio"
+	"flag"
 	"fmt"
+	"go/build"
+	"go/parser"
+	"go/token"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
+	"strings"
 )
 
 func main() {
-	args := os.Args[1:]
-
-	if len(args) < 1 {
-		fmt.Println("ERROR: Missing required positional arg: day")
-		os.Exit(1)
-	}
-
-	day, err := strconv.Atoi(args[0])
+	loaded, err := loadConfig()
 	if err != nil {
 		logErr(err)
 	}
 
-	err = os.Mkdir(fmt.Sprintf("days/%d", day), 0700)
-	if err != nil {
-		logErr(err)
-	}
+	config = loaded
 
-	if createMod(day) != nil {
-		logErr(err)
-	}
-
-	os.Exit(0)
+	os.Exit(runCLI(os.Args[1:]))
 }
 
 func logErr(err error) {
 	fmt.Printf("ERROR: %s\n", err.Error())
-	os.Exit(1)
+	os.Exit(exitFailure)
+}
+
+func newCommand(flags *flag.FlagSet) func(args, extra []string) error {
+	year := yearFlag(flags)
+
+	return func(args, extra []string) error {
+		day, err := dayArg(*year, args)
+		if err != nil {
+			return err
+		}
+
+		dir := dayDir(*year, day)
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return fmt.Errorf("day %d already exists in %s", day, dir)
+		}
+
+		_, statErr := os.Stat(dir)
+		existed := statErr == nil
+
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return err
+		}
+
+		if err := createMod(*year, day); err != nil {
+			// Cleaned up so the day can be created again once the cause is fixed
+			if existed {
+				os.Remove(filepath.Join(dir, "go.mod"))
+			} else {
+				os.RemoveAll(dir)
+			}
+
+			return err
+		}
+
+		return nil
+	}
+}
+
+func checkCommand(flags *flag.FlagSet) func(args, extra []string) error {
+	return func(args, extra []string) error {
+		if len(args) > 0 {
+			return usagef("check doesn't take any args")
+		}
+
+		problems, err := checkImports(dayDirPattern())
+		if err != nil {
+			return err
+		}
+
+		for _, problem := range problems {
+			fmt.Println(problem)
+		}
+
+		if len(problems) > 0 {
+			return fmt.Errorf("%d problem(s) found", len(problems))
+		}
+
+		return nil
+	}
 }
 
-func createMod(day int) error {
+func createMod(year, day int) error {
 	cmd := exec.Command("go", "mod", "init", fmt.Sprintf("d%d", day))
 
-	cmd.Dir = getWorkingDir(day)
+	cmd.Dir = dayDir(year, day)
 
-	err := cmd.Run()
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("go mod init: %w: %s", err, strings.TrimSpace(string(output)))
+	}
 
+	// The template reads inputs through this repo's own module. Both paths are
+	// made absolute first, as days_dir may be either.
+	root, err := filepath.Abs(".")
 	if err != nil {
 		return err
 	}
 
-	return createFiles(day)
+	dir, err := filepath.Abs(cmd.Dir)
+	if err != nil {
+		return err
+	}
+
+	relative, err := filepath.Rel(dir, root)
+	if err != nil {
+		return err
+	}
+
+	editCmd := exec.Command("go", "mod", "edit", "-require", localModule+"@v0.0.0", "-replace", localModule+"="+filepath.ToSlash(relative))
+
+	editCmd.Dir = cmd.Dir
+
+	if output, err := editCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("go mod edit: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return createFiles(year, day)
 }
 
-func createFiles(day int) error {
+func createFiles(year, day int) error {
 	touchCmd := exec.Command("touch", "sample.txt", "input.txt")
 
-	touchCmd.Dir = getWorkingDir(day)
+	touchCmd.Dir = dayDir(year, day)
+
+	if output, err := touchCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("creating the input files: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	cpCmd := exec.Command("cp", config.Template, filepath.Join(dayDir(year, day), "main.go"))
 
-	err := touchCmd.Run()
+	if output, err := cpCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("copying the template: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// Advent of Code started in 2015
+const firstYear = 2015
+
+func checkYear(year int) error {
+	if year < firstYear {
+		return fmt.Errorf("invalid year: %d", year)
+	}
+
+	return nil
+}
+
+// yearFlag adds --year to a command's flags
+func yearFlag(flags *flag.FlagSet) *int {
+	return flags.Int("year", config.Year, "which year's puzzles to use")
+}
+
+func dayDir(year, day int) string {
+	return filepath.Join(strings.ReplaceAll(config.DaysDir, "{year}", strconv.Itoa(year)), strconv.Itoa(day))
+}
+
+// dayDirPattern globs every day's directory, in every year
+func dayDirPattern() string {
+	return filepath.Join(strings.ReplaceAll(config.DaysDir, "{year}", "*"), "*")
+}
+
+// The repo's own module, which the days may import shared packages from
+const localModule = "aoc"
+
+func isAllowedImport(path string) bool {
+	if path == localModule || strings.HasPrefix(path, localModule+"/") {
+		return true
+	}
+
+	// Only the standard library can do without a dot in its first element
+	if strings.Contains(strings.Split(path, "/")[0], ".") {
+		return false
+	}
+
+	info, err := os.Stat(filepath.Join(build.Default.GOROOT, "src", filepath.FromSlash(path)))
+
+	return err == nil && info.IsDir()
+}
+
+// checkImports reports every import in the day modules matched by the
+// pattern that is neither part of the standard library nor this repo, and
+// any go.mod requiring another module.
+func checkImports(daysPattern string) ([]string, error) {
+	problems := make([]string, 0)
 
+	files, err := filepath.Glob(filepath.Join(daysPattern, "*.go"))
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, parser.ImportsOnly)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, imp := range f.Imports {
+			path, err := strconv.Unquote(imp.Path.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			if !isAllowedImport(path) {
+				problems = append(problems, fmt.Sprintf("%s: non-stdlib import %q", fset.Position(imp.Pos()), path))
+			}
+		}
+	}
+
+	mods, err := filepath.Glob(filepath.Join(daysPattern, "go.mod"))
+	if err != nil {
+		return nil, err
 	}
 
-	cpCmd := exec.Command("cp", "template/template.go", fmt.Sprintf("days/%d/main.go", day))
+	for _, mod := range mods {
+		required, err := requiredModules(mod)
+		if err != nil {
+			return nil, err
+		}
 
-	return cpCmd.Run()
+		for _, module := range required {
+			if module != localModule {
+				problems = append(problems, fmt.Sprintf("%s: requires %q", mod, module))
+			}
+		}
+	}
+
+	return problems, nil
 }
 
-func getWorkingDir(day int) string {
-	return fmt.Sprintf("/home/iacna/dev/AOC2024/days/%d", day)
+// requiredModules lists the modules in a go.mod's require directives
+func requiredModules(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	modules := make([]string, 0)
+	inBlock := false
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if idx := strings.Index(line, "//"); idx != -1 {
+			line = strings.TrimSpace(line[:idx])
+		}
+
+		switch {
+		case line == "require (":
+			inBlock = true
+		case inBlock && line == ")":
+			inBlock = false
+		case inBlock && line != "":
+			modules = append(modules, strings.Fields(line)[0])
+		case strings.HasPrefix(line, "require "):
+			modules = append(modules, strings.Fields(line)[1])
+		}
+	}
+
+	return modules, scanner.Err()
 }
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// fetchPuzzle downloads the puzzle page for a day. Part 2 only appears on the
+// page when the request carries the session cookie from a logged in browser.
+func fetchPuzzle(year, day int) (string, error) {
+	return siteRequest(http.MethodGet, fmt.Sprintf("/%d/day/%d", year, day), nil, false)
+}
+
+var articlePattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+
+// puzzleArticles returns the inner HTML of each part's description, in order
+func puzzleArticles(page string) ([]string, error) {
+	matches := articlePattern.FindAllStringSubmatch(page, -1)
+	if len(matches) == 0 {
+		return nil, errors.New("no puzzle description found in the page")
+	}
+
+	articles := make([]string, 0, len(matches))
+	for _, match := range matches {
+		articles = append(articles, match[1])
+	}
+
+	return articles, nil
+}
+
+var (
+	tagPattern        = regexp.MustCompile(`<(/?)([a-zA-Z0-9]+)([^>]*)>`)
+	hrefPattern       = regexp.MustCompile(`href="([^"]*)"`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// markdownWriter converts the small set of tags used in puzzle descriptions,
+// anything else is dropped, keeping its text.
+type markdownWriter struct {
+	sb     strings.Builder
+	inPre  bool
+	inCode bool
+	links  []string
+}
+
+func (m *markdownWriter) text(text string) {
+	text = html.UnescapeString(text)
+
+	if !m.inPre {
+		text = whitespacePattern.ReplaceAllString(text, " ")
+
+		// Line breaks in the HTML between blocks would otherwise indent the next one
+		if m.sb.Len() == 0 || strings.HasSuffix(m.sb.String(), "\n") {
+			text = strings.TrimLeft(text, " ")
+		}
+	}
+
+	m.sb.WriteString(text)
+}
+
+func (m *markdownWriter) open(name, attrs string) {
+	switch name {
+	case "h2":
+		m.sb.WriteString("\n\n## ")
+	case "p", "ul":
+		m.sb.WriteString("\n\n")
+	case "li":
+		m.sb.WriteString("\n- ")
+	case "pre":
+		m.sb.WriteString("\n\n```\n")
+		m.inPre = true
+	case "code":
+		if !m.inPre {
+			m.sb.WriteString("`")
+			m.inCode = true
+		}
+	case "em":
+		if !m.inPre && !m.inCode {
+			m.sb.WriteString("**")
+		}
+	case "a":
+		href := ""
+		if match := hrefPattern.FindStringSubmatch(attrs); match != nil {
+			href = html.UnescapeString(match[1])
+		}
+
+		if strings.HasPrefix(href, "/") {
+			href = siteURL + href
+		}
+
+		m.links = append(m.links, href)
+		m.sb.WriteString("[")
+	case "br":
+		m.sb.WriteString("  \n")
+	}
+}
+
+func (m *markdownWriter) close(name string) {
+	switch name {
+	case "h2", "p", "ul":
+		m.sb.WriteString("\n\n")
+	case "pre":
+		if !strings.HasSuffix(m.sb.String(), "\n") {
+			m.sb.WriteString("\n")
+		}
+
+		m.sb.WriteString("```\n\n")
+		m.inPre = false
+	case "code":
+		if !m.inPre {
+			m.sb.WriteString("`")
+			m.inCode = false
+		}
+	case "em":
+		if !m.inPre && !m.inCode {
+			m.sb.WriteString("**")
+		}
+	case "a":
+		if len(m.links) == 0 {
+			return
+		}
+
+		href := m.links[len(m.links)-1]
+		m.links = m.links[:len(m.links)-1]
+
+		fmt.Fprintf(&m.sb, "](%s)", href)
+	}
+}
+
+func (m *markdownWriter) String() string {
+	result := blankLinesPattern.ReplaceAllString(m.sb.String(), "\n\n")
+
+	lines := strings.Split(strings.TrimSpace(result), "\n")
+	for idx, line := range lines {
+		// Only the spaces from collapsing the HTML's own line breaks
+		if !strings.HasSuffix(line, "  ") {
+			lines[idx] = strings.TrimRight(line, " ")
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func htmlToMarkdown(fragment string) string {
+	m := &markdownWriter{}
+	last := 0
+
+	for _, match := range tagPattern.FindAllStringSubmatchIndex(fragment, -1) {
+		m.text(fragment[last:match[0]])
+		last = match[1]
+
+		name := strings.ToLower(fragment[match[4]:match[5]])
+		if fragment[match[2]:match[3]] == "/" {
+			m.close(name)
+		} else {
+			m.open(name, fragment[match[6]:match[7]])
+		}
+	}
+
+	m.text(fragment[last:])
+
+	return m.String()
+}
+
+// puzzleMarkdown converts every part found in the page, part 2 is only there
+// once part 1 has been solved.
+func puzzleMarkdown(page string) (string, error) {
+	articles, err := puzzleArticles(page)
+	if err != nil {
+		return "", err
+	}
+
+	parts := make([]string, 0, len(articles))
+	for _, article := range articles {
+		parts = append(parts, htmlToMarkdown(article))
+	}
+
+	return strings.Join(parts, "\n\n") + "\n", nil
+}
+
+// getPuzzlePage reads the page from a saved file when one is given, otherwise
+// downloads it and keeps a copy next to the day.
+func getPuzzlePage(year, day int, savedPath string) (string, error) {
+	if savedPath != "" {
+		data, err := os.ReadFile(savedPath)
+		if err != nil {
+			return "", err
+		}
+
+		return string(data), nil
+	}
+
+	page, err := fetchPuzzle(year, day)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.WriteFile(filepath.Join(dayDir(year, day), "puzzle.html"), []byte(page), 0600); err != nil {
+		return "", err
+	}
+
+	return page, nil
+}
+
+// describeCommand saves the puzzle text for a day as Markdown, or prints it
+// when the output is "-".
+func describeCommand(flags *flag.FlagSet) func(args, extra []string) error {
+	savedPath := flags.String("html", "", "convert this saved puzzle page instead of downloading it")
+	output := flags.String("o", "", "where to write the Markdown, - for stdout (default <day dir>/PUZZLE.md)")
+	year := yearFlag(flags)
+
+	return func(args, extra []string) error {
+		day, err := dayArg(*year, args)
+		if err != nil {
+			return err
+		}
+
+		page, err := getPuzzlePage(*year, day, *savedPath)
+		if err != nil {
+			return err
+		}
+
+		markdown, err := puzzleMarkdown(page)
+		if err != nil {
+			return err
+		}
+
+		switch *output {
+		case "-":
+			_, err = fmt.Print(markdown)
+			return err
+		case "":
+			return os.WriteFile(filepath.Join(dayDir(*year, day), "PUZZLE.md"), []byte(markdown), 0600)
+		default:
+			return os.WriteFile(*output, []byte(markdown), 0600)
+		}
+	}
+}
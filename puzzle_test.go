@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func readFixture(t *testing.T) string {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/puzzle.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
+
+func TestPuzzleMarkdown(t *testing.T) {
+	markdown, err := puzzleMarkdown(readFixture(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"## --- Day 1: Counting Lanterns ---",
+		"## --- Part Two ---",
+		"[the about page](https://adventofcode.com/2024/about)",
+		"strung up **lanterns** all along",
+		"**What is the brightness of the path?**",
+		"a brightness of `9`.",
+		"- Lanterns brighter than `2` count fully.",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("expected %q in:\n%s", want, markdown)
+		}
+	}
+
+	if strings.Index(markdown, "Counting Lanterns") > strings.Index(markdown, "Part Two") {
+		t.Error("expected part 1 before part 2")
+	}
+
+	// The second example highlights a line, which can't be shown in a fenced block
+	if !strings.Contains(markdown, "```\n3\n7\n2\n5\n```") {
+		t.Errorf("expected the part 2 example without emphasis in:\n%s", markdown)
+	}
+}
@@ -0,0 +1,229 @@
+// Package render turns the 2D cell grids used by the puzzles into PNG or SVG
+// images.
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Background is used for cells without a colour, and for the gaps left by
+// rows that are shorter than the widest one.
+var Background = color.RGBA{0x0f, 0x0f, 0x23, 0xff}
+
+// Grid describes how to draw a grid of cells of any type
+type Grid[T any] struct {
+	Cells [][]T
+	// Colour maps a cell to its fill, nil means Background.
+	Colour func(T) color.Color
+	// Label is optional, and only used by SVG output to write text on a cell.
+	Label func(T) string
+	// Scale is the size of each cell in pixels, defaults to 8.
+	Scale int
+}
+
+func (g Grid[T]) scale() int {
+	if g.Scale <= 0 {
+		return 8
+	}
+
+	return g.Scale
+}
+
+func (g Grid[T]) size() (int, int) {
+	width := 0
+
+	for _, row := range g.Cells {
+		width = max(width, len(row))
+	}
+
+	return width, len(g.Cells)
+}
+
+func (g Grid[T]) colour(cell T) color.Color {
+	if g.Colour == nil {
+		return Background
+	}
+
+	if c := g.Colour(cell); c != nil {
+		return c
+	}
+
+	return Background
+}
+
+// Image draws the grid into an in memory image
+func (g Grid[T]) Image() *image.RGBA {
+	scale := g.scale()
+	width, height := g.size()
+
+	img := image.NewRGBA(image.Rect(0, 0, width*scale, height*scale))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.Color(Background)
+			if x < len(g.Cells[y]) {
+				c = g.colour(g.Cells[y][x])
+			}
+
+			for py := y * scale; py < (y+1)*scale; py++ {
+				for px := x * scale; px < (x+1)*scale; px++ {
+					img.Set(px, py, c)
+				}
+			}
+		}
+	}
+
+	return img
+}
+
+// PNG writes the grid as a PNG image
+func (g Grid[T]) PNG(w io.Writer) error {
+	return png.Encode(w, g.Image())
+}
+
+func hex(c color.Color) string {
+	r, gr, b, _ := c.RGBA()
+
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, gr>>8, b>>8)
+}
+
+// SVG writes the grid as an SVG document, one rect per cell
+func (g Grid[T]) SVG(w io.Writer) error {
+	scale := g.scale()
+	width, height := g.size()
+
+	out := bufio.NewWriter(w)
+
+	fmt.Fprintf(out, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		width*scale, height*scale, width*scale, height*scale)
+	fmt.Fprintf(out, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hex(Background))
+
+	for y, row := range g.Cells {
+		for x, cell := range row {
+			fill := hex(g.colour(cell))
+
+			fmt.Fprintf(out, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+				x*scale, y*scale, scale, scale, fill)
+
+			if g.Label == nil {
+				continue
+			}
+
+			label := g.Label(cell)
+			if label == "" {
+				continue
+			}
+
+			fmt.Fprintf(out, `<text x="%g" y="%g" font-size="%g" font-family="monospace" text-anchor="middle" dominant-baseline="central" fill="%s">%s</text>`+"\n",
+				(float64(x)+0.5)*float64(scale), (float64(y)+0.5)*float64(scale), float64(scale)*0.8, contrast(g.colour(cell)), escape(label))
+		}
+	}
+
+	fmt.Fprintln(out, "</svg>")
+
+	return out.Flush()
+}
+
+var escaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// contrast picks black or white text, whichever is easier to read on c
+func contrast(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+
+	luma := 0.299*float64(r>>8) + 0.587*float64(g>>8) + 0.114*float64(b>>8)
+	if luma > 140 {
+		return "#000000"
+	}
+
+	return "#ffffff"
+}
+
+// Save writes the grid to path, picking PNG or SVG from the file extension
+func (g Grid[T]) Save(path string) error {
+	var write func(io.Writer) error
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png":
+		write = g.PNG
+	case ".svg":
+		write = g.SVG
+	default:
+		return fmt.Errorf("unsupported image format: %q", path)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// Heat maps value onto a blue to red gradient, where low is fully blue and
+// high fully red.
+func Heat(value, low, high float64) color.Color {
+	t := 0.0
+	if high > low {
+		t = math.Min(math.Max((value-low)/(high-low), 0), 1)
+	}
+
+	// Blue -> cyan -> yellow -> red, through hue 240 down to 0
+	return hsv(240*(1-t), 0.85, 0.95)
+}
+
+// Distinct returns the ith of n colours spread evenly around the colour wheel
+func Distinct(i, n int) color.Color {
+	if n <= 0 {
+		n = 1
+	}
+
+	return hsv(360*float64(i%n)/float64(n), 0.7, 0.95)
+}
+
+func hsv(h, s, v float64) color.Color {
+	c := v * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := v - c
+
+	var r, g, b float64
+
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+
+	return color.RGBA{
+		uint8(math.Round((r + m) * 255)),
+		uint8(math.Round((g + m) * 255)),
+		uint8(math.Round((b + m) * 255)),
+		0xff,
+	}
+}
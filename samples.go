@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	prePattern        = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	answerPattern     = regexp.MustCompile(`(?s)<code><em>(.*?)</em></code>`)
+	forExamplePattern = regexp.MustCompile(`(?i)for\s+example`)
+)
+
+// stripTags returns the text of an HTML fragment
+func stripTags(fragment string) string {
+	return html.UnescapeString(tagPattern.ReplaceAllString(fragment, ""))
+}
+
+// PartSample is what could be found for one part of the puzzle
+type PartSample struct {
+	// Every example block introduced with "For example", in order
+	candidates []string
+	// Empty when the part has no highlighted code
+	answer string
+}
+
+// findSamples picks out the samples and answers from each part's description.
+// It is only a guess, the examples are usually introduced with "For example"
+// and the sample's answer is usually the last highlighted piece of code.
+func findSamples(page string) ([]PartSample, error) {
+	articles, err := puzzleArticles(page)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]PartSample, 0, len(articles))
+
+	for _, article := range articles {
+		part := PartSample{candidates: make([]string, 0)}
+		last := 0
+
+		for _, match := range prePattern.FindAllStringSubmatchIndex(article, -1) {
+			if forExamplePattern.MatchString(stripTags(article[last:match[0]])) {
+				part.candidates = append(part.candidates, stripTags(article[match[2]:match[3]]))
+			}
+
+			last = match[1]
+		}
+
+		if answers := answerPattern.FindAllStringSubmatch(article, -1); len(answers) > 0 {
+			part.answer = strings.TrimSpace(stripTags(answers[len(answers)-1][1]))
+		}
+
+		parts = append(parts, part)
+	}
+
+	return parts, nil
+}
+
+// writeSamples saves every candidate as sample.<part>.<n>.txt under dir, and
+// fills in sample.txt with the first one when it is empty. The answers go in
+// sample.answers.txt, written the way the days print them.
+func writeSamples(dir string, parts []PartSample) ([]string, error) {
+	written := make([]string, 0)
+	answers := make([]string, 0, len(parts))
+	first := ""
+
+	for partIdx, part := range parts {
+		for idx, candidate := range part.candidates {
+			path := filepath.Join(dir, fmt.Sprintf("sample.%d.%d.txt", partIdx+1, idx+1))
+			if err := os.WriteFile(path, []byte(candidate), 0600); err != nil {
+				return nil, err
+			}
+
+			written = append(written, path)
+
+			if first == "" {
+				first = candidate
+			}
+		}
+
+		if part.answer != "" {
+			answers = append(answers, fmt.Sprintf("Part %d: %s\n", partIdx+1, part.answer))
+		}
+	}
+
+	if len(written) == 0 {
+		return nil, errors.New("no examples found in the puzzle")
+	}
+
+	samplePath := filepath.Join(dir, "sample.txt")
+	existing, err := os.ReadFile(samplePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	if strings.TrimSpace(string(existing)) == "" {
+		if err := os.WriteFile(samplePath, []byte(first), 0600); err != nil {
+			return nil, err
+		}
+
+		written = append(written, samplePath)
+	}
+
+	answersPath := filepath.Join(dir, "sample.answers.txt")
+	if err := os.WriteFile(answersPath, []byte(strings.Join(answers, "")), 0600); err != nil {
+		return nil, err
+	}
+
+	return append(written, answersPath), nil
+}
+
+// samplesCommand extracts the examples from a day's puzzle page. The page is
+// read from --html, then the copy describe saved, and only downloaded when
+// there is neither.
+func samplesCommand(flags *flag.FlagSet) func(args, extra []string) error {
+	savedPath := flags.String("html", "", "read this saved puzzle page instead of the day's puzzle.html")
+	outDir := flags.String("dir", "", "where to write the samples (default the day's directory)")
+	year := yearFlag(flags)
+
+	return func(args, extra []string) error {
+		day, err := dayArg(*year, args)
+		if err != nil {
+			return err
+		}
+
+		if *savedPath == "" {
+			cached := filepath.Join(dayDir(*year, day), "puzzle.html")
+			if _, err := os.Stat(cached); err == nil {
+				*savedPath = cached
+			}
+		}
+
+		if *outDir == "" {
+			*outDir = dayDir(*year, day)
+		}
+
+		page, err := getPuzzlePage(*year, day, *savedPath)
+		if err != nil {
+			return err
+		}
+
+		parts, err := findSamples(page)
+		if err != nil {
+			return err
+		}
+
+		written, err := writeSamples(*outDir, parts)
+		if err != nil {
+			return err
+		}
+
+		for partIdx, part := range parts {
+			answer := part.answer
+			if answer == "" {
+				answer = "not found"
+			}
+
+			fmt.Printf("Part %d: %d example(s), answer %s\n", partIdx+1, len(part.candidates), answer)
+		}
+
+		for _, path := range written {
+			fmt.Printf("wrote %s\n", path)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteSamples(t *testing.T) {
+	parts, err := findSamples(readFixture(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if _, err := writeSamples(dir, parts); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range map[string]string{
+		"sample.1.1.txt":     "3\n4\n2\n",
+		"sample.2.1.txt":     "3\n7\n2\n5\n",
+		"sample.txt":         "3\n4\n2\n",
+		"sample.answers.txt": "Part 1: 9\nPart 2: 7\n",
+	} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if string(data) != want {
+			t.Errorf("%s: got %q, expected %q", name, data, want)
+		}
+	}
+}
+
+func TestWriteSamplesKeepsSample(t *testing.T) {
+	parts, err := findSamples(readFixture(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	samplePath := filepath.Join(dir, "sample.txt")
+
+	if err := os.WriteFile(samplePath, []byte("1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := writeSamples(dir, parts); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(samplePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != "1\n" {
+		t.Errorf("sample.txt was overwritten with %q", data)
+	}
+}
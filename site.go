@@ -0,0 +1,168 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	siteURL = "https://adventofcode.com"
+	// Advent of Code asks automated requests to say where they come from
+	userAgent = "aoc command line tool"
+)
+
+// siteRequest makes a request to the Advent of Code site, posting the form
+// when there is one. The session cookie is sent when it is set, and has to
+// be when needSession is true.
+func siteRequest(method, path string, form url.Values, needSession bool) (string, error) {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequest(method, siteURL+path, body)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	session, err := config.session()
+	if err != nil {
+		return "", err
+	}
+
+	if session != "" {
+		req.AddCookie(&http.Cookie{Name: "session", Value: session})
+	} else if needSession {
+		return "", errors.New("no session cookie, set AOC_SESSION or session_file in the config")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetching %s: %s", req.URL, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func fetchCommand(flags *flag.FlagSet) func(args, extra []string) error {
+	year := yearFlag(flags)
+	force := flags.Bool("force", false, "overwrite an input that is already there")
+
+	return func(args, extra []string) error {
+		day, err := dayArg(*year, args)
+		if err != nil {
+			return err
+		}
+
+		dir := dayDir(*year, day)
+		if hasInput(dir) && !*force {
+			return fmt.Errorf("%s already has an input, pass --force to replace it", dir)
+		}
+
+		input, err := siteRequest(http.MethodGet, fmt.Sprintf("/%d/day/%d/input", *year, day), nil, true)
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(dir, inputName)
+		if err := os.WriteFile(path, []byte(input), 0600); err != nil {
+			return err
+		}
+
+		fmt.Printf("wrote %s, run \"%s inputs lock %d\" before committing it\n", path, programName, day)
+
+		return nil
+	}
+}
+
+// dayAnswer runs a day against its input and returns its answer to a part
+func dayAnswer(year, day, part int) (string, error) {
+	output, err := dayOutput(dayDir(year, day), dayArgs(false, nil))
+	if err != nil {
+		return "", err
+	}
+
+	answer, ok := parseAnswers(output)[part]
+	if !ok {
+		return "", fmt.Errorf("day %d didn't print an answer for part %d", day, part)
+	}
+
+	return answer, nil
+}
+
+func submitCommand(flags *flag.FlagSet) func(args, extra []string) error {
+	year := yearFlag(flags)
+	dryRun := flags.Bool("dry-run", false, "print the answer that would be submitted without sending it")
+
+	return func(args, extra []string) error {
+		if len(args) < 2 || len(args) > 3 {
+			return usagef("expected a day, a part and optionally an answer")
+		}
+
+		day, err := dayArg(*year, args[:1])
+		if err != nil {
+			return err
+		}
+
+		part, err := strconv.Atoi(args[1])
+		if err != nil || (part != 1 && part != 2) {
+			return usagef("invalid part: %q", args[1])
+		}
+
+		var answer string
+		if len(args) == 3 {
+			answer = args[2]
+		} else if answer, err = dayAnswer(*year, day, part); err != nil {
+			return err
+		}
+
+		if *dryRun {
+			fmt.Printf("would submit %s for %d day %d part %d\n", answer, *year, day, part)
+			return nil
+		}
+
+		form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+
+		page, err := siteRequest(http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", *year, day), form, true)
+		if err != nil {
+			return err
+		}
+
+		response, err := puzzleMarkdown(page)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(response)
+
+		if !strings.Contains(response, "That's the right answer") {
+			return fmt.Errorf("%s was not accepted", answer)
+		}
+
+		return nil
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"aoc/inputs"
 )
 
 func logErr(err error) {
@@ -30,7 +32,7 @@ func getInput(args []string) (string, error) {
 		fileName = "sample.txt"
 	}
 
-	data, err := os.ReadFile(fileName)
+	data, err := inputs.ReadFile(fileName)
 	if err != nil {
 		return "", err
 	}
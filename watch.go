@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"aoc/inputs"
+)
+
+// fileStamp is enough to tell a file has changed without reading it
+type fileStamp struct {
+	modified time.Time
+	size     int64
+}
+
+// watchedFiles are the files in a day which trigger a rerun when they change
+func watchedFiles(dir string) ([]string, error) {
+	files := make([]string, 0)
+
+	for _, pattern := range []string{"*.go", "go.mod", "sample*.txt", inputName, inputName + inputs.Suffix} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, matches...)
+	}
+
+	slices.Sort(files)
+
+	return files, nil
+}
+
+// snapshot stamps every watched file. Polling the stamps, rather than asking
+// the OS for events, works the same on every platform and in containers.
+func snapshot(dir string) (map[string]fileStamp, error) {
+	files, err := watchedFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	stamps := make(map[string]fileStamp, len(files))
+
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if errors.Is(err, os.ErrNotExist) {
+			// Removed between the glob and the stat, e.g. an editor's save
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		stamps[file] = fileStamp{info.ModTime(), info.Size()}
+	}
+
+	return stamps, nil
+}
+
+// changedFiles lists the files added, removed or changed between snapshots
+func changedFiles(before, after map[string]fileStamp) []string {
+	changed := make([]string, 0)
+
+	for file, stamp := range after {
+		if previous, ok := before[file]; !ok || previous != stamp {
+			changed = append(changed, filepath.Base(file))
+		}
+	}
+
+	for file := range before {
+		if _, ok := after[file]; !ok {
+			changed = append(changed, filepath.Base(file))
+		}
+	}
+
+	slices.Sort(changed)
+
+	return changed
+}
+
+// watchRun builds the day once, then runs it against the sample and, when
+// there is one, the input, comparing the answers with the expected ones.
+func watchRun(year, day, part int, binary string) error {
+	dir := dayDir(year, day)
+
+	build := exec.Command("go", "build", "-o", binary, ".")
+	build.Dir = dir
+	build.Stdout = os.Stdout
+	build.Stderr = os.Stdout
+
+	if err := build.Run(); err != nil {
+		return fmt.Errorf("building %s: %w", dir, err)
+	}
+
+	runs := []struct {
+		name     string
+		sample   bool
+		expected func() (map[int]string, error)
+	}{
+		{"sample", true, func() (map[int]string, error) { return sampleAnswers(dir) }},
+		{"input", false, func() (map[int]string, error) { return inputAnswers(year, day) }},
+	}
+
+	failed := 0
+
+	for _, run := range runs {
+		if !run.sample && !hasInput(dir) {
+			continue
+		}
+
+		expected, err := run.expected()
+		if err != nil {
+			return err
+		}
+
+		cmd := exec.Command(binary, dayArgs(run.sample, nil)...)
+		cmd.Dir = dir
+		cmd.Stderr = os.Stdout
+
+		output, err := cmd.Output()
+		if err != nil {
+			fmt.Printf("%s: %s\n", run.name, strings.TrimSpace(string(output)))
+			return fmt.Errorf("running %s against its %s: %w", dir, run.name, err)
+		}
+
+		failed += compareAnswers(run.name, expected, parseAnswers(string(output)), part)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d answer(s) wrong", failed)
+	}
+
+	return nil
+}
+
+func watchCommand(flags *flag.FlagSet) func(args, extra []string) error {
+	year := yearFlag(flags)
+	part := flags.Int("part", 0, "only show this part's answers, 0 for both")
+	interval := flags.Duration("interval", 500*time.Millisecond, "how often to look for changes")
+	clearScreen := flags.Bool("clear", false, "clear the screen before each run")
+	once := flags.Bool("once", false, "run once and exit, with the exit code showing whether the answers matched")
+
+	return func(args, extra []string) error {
+		day, err := dayArg(*year, args)
+		if err != nil {
+			return err
+		}
+
+		if *part < 0 || *part > 2 {
+			return usagef("--part must be 0, 1 or 2")
+		}
+
+		if *interval <= 0 {
+			return usagef("--interval must be positive")
+		}
+
+		dir := dayDir(*year, day)
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err != nil {
+			return fmt.Errorf("no day in %s", dir)
+		}
+
+		tmp, err := os.MkdirTemp("", "aoc-watch")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmp)
+
+		binary := filepath.Join(tmp, "day")
+
+		if *once {
+			return watchRun(*year, day, *part, binary)
+		}
+
+		// Stopped with ctrl-c by returning, so the built binary is cleaned up
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		var previous map[string]fileStamp
+
+		for {
+			if previous != nil {
+				select {
+				case <-ctx.Done():
+					fmt.Println()
+					return nil
+				case <-time.After(*interval):
+				}
+			}
+
+			current, err := snapshot(dir)
+			if err != nil {
+				return err
+			}
+
+			reason := "starting"
+
+			if previous != nil {
+				changed := changedFiles(previous, current)
+				if len(changed) == 0 {
+					continue
+				}
+
+				reason = "changed " + strings.Join(changed, ", ")
+			}
+
+			previous = current
+
+			if *clearScreen {
+				fmt.Print("\x1b[H\x1b[2J")
+			}
+
+			fmt.Printf("--- %s, %s ---\n", time.Now().Format(time.TimeOnly), reason)
+
+			// A failed run is shown, then the watch carries on for the fix
+			if err := watchRun(*year, day, *part, binary); err != nil {
+				fmt.Printf("ERROR: %s\n", err.Error())
+			}
+
+			fmt.Printf("watching %s for changes, ctrl-c to stop\n", dir)
+		}
+	}
+}
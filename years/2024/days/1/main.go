@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"aoc/cmdline"
+	"aoc/inputs"
+)
+
+func logErr(err error) {
+	fmt.Printf("ERROR: %+v\n", err)
+
+	os.Exit(1)
+}
+
+func useSample(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	if args[0] == "sample" {
+		return true
+	}
+
+	return false
+}
+
+func getInput(args []string) (string, error) {
+	fileName := "input.txt"
+	if useSample(args) {
+		fileName = "sample.txt"
+	}
+
+	data, err := inputs.ReadFile(fileName)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Trim(string(data), "\n"), nil
+}
+
+var (
+	part1Metric = flag.String("part1", "distance", "metric used for part 1, see --metrics")
+	part2Metric = flag.String("part2", "similarity", "metric used for part 2, see --metrics")
+	listMetrics = flag.Bool("metrics", false, "list the metrics the lists can be compared with")
+)
+
+func main() {
+	args, err := cmdline.Parse(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		logErr(err)
+	}
+
+	if *listMetrics {
+		printMetrics()
+		return
+	}
+
+	input, err := getInput(args)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	p1, err := part1(input)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	fmt.Printf("Part 1: %d\n", p1)
+
+	p2, err := part2(input)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	fmt.Printf("Part 2: %d\n", p2)
+}
+
+type lists struct {
+	left, right []int
+}
+
+func getLists(input string) (*lists, error) {
+	lists, err := readLists(strings.NewReader(input))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lists.left) == 0 {
+		return nil, errors.New("invalid input")
+	}
+
+	return lists, nil
+}
+
+func measure(input, metricName string) (int, error) {
+	metric, err := getMetric(metricName)
+	if err != nil {
+		return -1, err
+	}
+
+	lists, err := getLists(input)
+	if err != nil {
+		return -1, err
+	}
+
+	return metric.measure(lists), nil
+}
+
+func part1(input string) (int, error) {
+	return measure(input, *part1Metric)
+}
+
+func part2(input string) (int, error) {
+	return measure(input, *part2Metric)
+}
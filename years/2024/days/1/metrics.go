@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Metric compares the two lists, producing a single number
+type Metric struct {
+	description string
+	measure     func(l *lists) int
+}
+
+var metrics = map[string]Metric{
+	"distance": {
+		"sum of the absolute differences between the sorted lists",
+		func(l *lists) int {
+			return sumPaired(l, func(left, right int) int {
+				return max(left-right, right-left)
+			})
+		},
+	},
+	"squared": {
+		"sum of the squared differences between the sorted lists",
+		func(l *lists) int {
+			return sumPaired(l, func(left, right int) int {
+				return (left - right) * (left - right)
+			})
+		},
+	},
+	"similarity": {
+		"each left value times how often it appears on the right",
+		func(l *lists) int {
+			return similarity(l.left, l.right)
+		},
+	},
+	"similarity-right": {
+		"each right value times how often it appears on the left",
+		func(l *lists) int {
+			return similarity(l.right, l.left)
+		},
+	},
+}
+
+func getMetric(name string) (Metric, error) {
+	metric, ok := metrics[name]
+	if !ok {
+		return Metric{}, fmt.Errorf("unknown metric %q, expected one of %s", name, strings.Join(metricNames(), ", "))
+	}
+
+	return metric, nil
+}
+
+// sumPaired sorts both lists, then sums f over each pair of values in the
+// same position.
+func sumPaired(l *lists, f func(left, right int) int) int {
+	left := slices.Sorted(slices.Values(l.left))
+	right := slices.Sorted(slices.Values(l.right))
+
+	result := 0
+
+	for idx := range left {
+		result += f(left[idx], right[idx])
+	}
+
+	return result
+}
+
+func counts(values []int) map[int]int {
+	result := make(map[int]int)
+
+	for _, value := range values {
+		result[value]++
+	}
+
+	return result
+}
+
+// similarity weights each value by the number of times it appears in the
+// other list.
+func similarity(values, other []int) int {
+	occurrences := counts(other)
+	result := 0
+
+	for _, value := range values {
+		result += value * occurrences[value]
+	}
+
+	return result
+}
+
+func metricNames() []string {
+	names := make([]string, 0, len(metrics))
+	for name := range metrics {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+func printMetrics() {
+	for _, name := range metricNames() {
+		fmt.Printf("%-16s %s\n", name, metrics[name].description)
+	}
+}
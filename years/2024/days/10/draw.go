@@ -0,0 +1,38 @@
+package main
+
+import (
+	"image/color"
+	"strconv"
+
+	"aoc/render"
+)
+
+// renderHeights writes the map as a heatmap, from height 0 in blue up to the
+// summits in red.
+func renderHeights(input, path string) error {
+	grid, err := newGrid(input)
+	if err != nil {
+		return err
+	}
+
+	image := render.Grid[*Cell]{
+		Cells: grid.cells,
+		Colour: func(c *Cell) color.Color {
+			if c.height == impassable {
+				return nil
+			}
+
+			return render.Heat(float64(c.height), 0, 9)
+		},
+		Label: func(c *Cell) string {
+			if c.height == impassable {
+				return ""
+			}
+
+			return strconv.Itoa(c.height)
+		},
+		Scale: 16,
+	}
+
+	return image.Save(path)
+}
@@ -0,0 +1,7 @@
+module d10
+
+go 1.23.0
+
+require aoc v0.0.0
+
+replace aoc => ../../../..
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"aoc/cmdline"
+	"aoc/inputs"
+)
+
+func logErr(err error) {
+	fmt.Printf("ERROR: %+v\n", err)
+
+	os.Exit(1)
+}
+
+func useSample(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	if args[0] == "sample" {
+		return true
+	}
+
+	return false
+}
+
+func getInput(args []string) (string, error) {
+	fileName := "input.txt"
+	if useSample(args) {
+		fileName = "sample.txt"
+	}
+
+	data, err := inputs.ReadFile(fileName)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Trim(string(data), "\n"), nil
+}
+
+var (
+	renderPath   = flag.String("render", "", "write a heatmap of the trail heights to this .png or .svg file")
+	trailsFormat = flag.String("trails", "", "print each trailhead's summits and trails as text, csv or json")
+	startHeight  = flag.Int("start", defaultTerrain.start, "height trails start from")
+	endHeight    = flag.Int("end", defaultTerrain.end, "height trails finish on")
+	stepRule     = flag.String("step", defaultTerrain.step.name, "allowed change in height per step: +1, ±1, <=+1 or a min..max range, ratings take exponential time unless every step goes the same way")
+	diagonal     = flag.Bool("diagonal", defaultTerrain.diagonal, "allow diagonal steps")
+)
+
+func main() {
+	args, err := cmdline.Parse(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		logErr(err)
+	}
+
+	input, err := getInput(args)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	terrain, err = newTerrain(*startHeight, *endHeight, *stepRule, *diagonal)
+	if err != nil {
+		logErr(err)
+	}
+
+	if *renderPath != "" {
+		if err := renderHeights(input, *renderPath); err != nil {
+			logErr(err)
+		}
+	}
+
+	if *trailsFormat != "" {
+		if err := writeTrails(os.Stdout, input, *trailsFormat); err != nil {
+			logErr(err)
+		}
+		return
+	}
+
+	p1, err := part1(input)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	fmt.Printf("Part 1: %d\n", p1)
+
+	p2, err := part2(input)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	fmt.Printf("Part 2: %d\n", p2)
+}
+
+type Cell struct {
+	x, y, height int
+}
+
+type Grid struct {
+	cells   [][]*Cell
+	terrain Terrain
+}
+
+func newGrid(input string) (*Grid, error) {
+	cells := make([][]*Cell, 0)
+
+	for y, line := range strings.Split(input, "\n") {
+		row := make([]*Cell, 0)
+
+		for x, col := range strings.Split(line, "") {
+			if col == "." {
+				row = append(row, &Cell{x, y, impassable})
+				continue
+			}
+
+			height, err := strconv.Atoi(col)
+			if err != nil {
+				return nil, err
+			}
+
+			row = append(row, &Cell{x, y, height})
+		}
+
+		cells = append(cells, row)
+	}
+
+	return &Grid{cells, terrain}, nil
+}
+
+func (g *Grid) getCellNeighbours(c *Cell) []*Cell {
+	neighbours := make([]*Cell, 0)
+
+	for _, dir := range g.terrain.directions() {
+		x, y := c.x+dir.x, c.y+dir.y
+
+		if y < 0 || y >= len(g.cells) || x < 0 || x >= len(g.cells[y]) {
+			continue
+		}
+
+		neighbours = append(neighbours, g.cells[y][x])
+	}
+
+	return neighbours
+}
+
+func part1(input string) (int, error) {
+	result := 0
+
+	grid, err := newGrid(input)
+	if err != nil {
+		return -1, err
+	}
+
+	for _, head := range grid.trailheads() {
+		result += len(grid.reachableSummits(head))
+	}
+
+	return result, nil
+}
+
+func part2(input string) (int, error) {
+	result := 0
+
+	grid, err := newGrid(input)
+	if err != nil {
+		return -1, err
+	}
+
+	for _, report := range grid.trailReports() {
+		result += report.Rating
+	}
+
+	return result, nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Cells marked with this can never be walked on
+const impassable = -1
+
+// StepRule decides which changes in height a single step may make
+type StepRule struct {
+	name     string
+	min, max int
+	// Whether a step of 0 is allowed when min <= 0 <= max
+	level bool
+}
+
+func (s StepRule) allows(delta int) bool {
+	if delta == 0 && !s.level {
+		return false
+	}
+
+	return delta >= s.min && delta <= s.max
+}
+
+// acyclic is true when every step goes strictly up or strictly down, so no
+// trail can ever come back to a cell it has already been on.
+func (s StepRule) acyclic() bool {
+	return s.min > 0 || s.max < 0 || (s.min == 0 && !s.level) || (s.max == 0 && !s.level)
+}
+
+func (s StepRule) String() string {
+	return s.name
+}
+
+var stepPresets = map[string]StepRule{
+	"+1":   {"+1", 1, 1, false},
+	"±1":   {"±1", -1, 1, false},
+	"+-1":  {"±1", -1, 1, false},
+	"<=+1": {"<=+1", -9, 1, true},
+	"<=1":  {"<=+1", -9, 1, true},
+}
+
+// parseStepRule accepts one of the presets, or a range written "min..max"
+// which allows every change in height between the two, inclusive.
+func parseStepRule(rule string) (StepRule, error) {
+	if preset, ok := stepPresets[rule]; ok {
+		return preset, nil
+	}
+
+	bounds := strings.Split(rule, "..")
+	if len(bounds) != 2 {
+		return StepRule{}, fmt.Errorf("invalid step rule: %q", rule)
+	}
+
+	low, err := strconv.Atoi(bounds[0])
+	if err != nil {
+		return StepRule{}, fmt.Errorf("invalid step rule: %q", rule)
+	}
+
+	high, err := strconv.Atoi(bounds[1])
+	if err != nil || high < low {
+		return StepRule{}, fmt.Errorf("invalid step rule: %q", rule)
+	}
+
+	return StepRule{rule, low, high, true}, nil
+}
+
+// Terrain holds the rules a trail has to follow
+type Terrain struct {
+	start, end int
+	step       StepRule
+	diagonal   bool
+}
+
+var defaultTerrain = Terrain{
+	start:    0,
+	end:      9,
+	step:     stepPresets["+1"],
+	diagonal: false,
+}
+
+// terrain is used by every grid, main replaces it from the flags
+var terrain = defaultTerrain
+
+func newTerrain(start, end int, step string, diagonal bool) (Terrain, error) {
+	if start < 0 || start > 9 || end < 0 || end > 9 {
+		return Terrain{}, fmt.Errorf("heights must be between 0 and 9, got %d and %d", start, end)
+	}
+
+	rule, err := parseStepRule(step)
+	if err != nil {
+		return Terrain{}, err
+	}
+
+	return Terrain{start, end, rule, diagonal}, nil
+}
+
+func (t Terrain) directions() []struct{ x, y int } {
+	directions := []struct{ x, y int }{
+		{0, -1},
+		{-1, 0}, {1, 0},
+		{0, 1},
+	}
+
+	if t.diagonal {
+		directions = append(directions, []struct{ x, y int }{
+			{-1, -1}, {1, -1},
+			{-1, 1}, {1, 1},
+		}...)
+	}
+
+	return directions
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Trail is every cell walked from a trailhead up to a summit, in order
+type Trail []*Cell
+
+func (t Trail) String() string {
+	steps := make([]string, len(t))
+
+	for idx, cell := range t {
+		steps[idx] = fmt.Sprintf("(%d,%d)", cell.x, cell.y)
+	}
+
+	return strings.Join(steps, " -> ")
+}
+
+func (g *Grid) isStep(from, to *Cell) bool {
+	if from.height == impassable || to.height == impassable {
+		return false
+	}
+
+	return g.terrain.step.allows(to.height - from.height)
+}
+
+func (g *Grid) isSummit(c *Cell) bool {
+	return c.height == g.terrain.end
+}
+
+func (g *Grid) trailheads() []*Cell {
+	heads := make([]*Cell, 0)
+
+	for _, row := range g.cells {
+		for _, cell := range row {
+			if cell.height == g.terrain.start {
+				heads = append(heads, cell)
+			}
+		}
+	}
+
+	return heads
+}
+
+// trails lists every distinct trail from the given cell to a summit. A trail
+// never visits the same cell twice, and finishes on the first summit it meets.
+func (g *Grid) trails(from *Cell) []Trail {
+	return g.walkTrails(from, make(map[*Cell]bool))
+}
+
+func (g *Grid) walkTrails(from *Cell, onTrail map[*Cell]bool) []Trail {
+	if g.isSummit(from) {
+		return []Trail{{from}}
+	}
+
+	result := make([]Trail, 0)
+	onTrail[from] = true
+
+	for _, neighbour := range g.getCellNeighbours(from) {
+		if onTrail[neighbour] || !g.isStep(from, neighbour) {
+			continue
+		}
+
+		for _, rest := range g.walkTrails(neighbour, onTrail) {
+			result = append(result, append(Trail{from}, rest...))
+		}
+	}
+
+	delete(onTrail, from)
+
+	return result
+}
+
+// reachableSummits finds every summit a trail from the given cell can reach,
+// with a breadth first search. Unlike listing the trails, it takes the same
+// time whatever the step rule.
+func (g *Grid) reachableSummits(from *Cell) []*Cell {
+	summits := make([]*Cell, 0)
+	seen := map[*Cell]bool{from: true}
+	queue := []*Cell{from}
+
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+
+		// Trails finish on the first summit they meet
+		if g.isSummit(cell) {
+			summits = append(summits, cell)
+			continue
+		}
+
+		for _, neighbour := range g.getCellNeighbours(cell) {
+			if seen[neighbour] || !g.isStep(cell, neighbour) {
+				continue
+			}
+
+			seen[neighbour] = true
+			queue = append(queue, neighbour)
+		}
+	}
+
+	return summits
+}
+
+// summitPaths maps each reachable summit to the number of distinct trails
+// leading to it.
+type summitPaths map[*Cell]int
+
+// pathCounter works out the trails from every cell once, so any cells shared
+// between trails are only walked the first time they are reached. That only
+// holds when trails can't loop back on themselves, otherwise the trails from
+// a cell depend on how it was reached, and each one has to be walked, which
+// takes exponential time.
+type pathCounter struct {
+	grid  *Grid
+	cache map[*Cell]summitPaths
+}
+
+func newPathCounter(g *Grid) *pathCounter {
+	return &pathCounter{g, make(map[*Cell]summitPaths)}
+}
+
+func (p *pathCounter) count(from *Cell) summitPaths {
+	if !p.grid.terrain.step.acyclic() {
+		paths := make(summitPaths)
+
+		for _, trail := range p.grid.trails(from) {
+			paths[trail[len(trail)-1]]++
+		}
+
+		return paths
+	}
+
+	if paths, ok := p.cache[from]; ok {
+		return paths
+	}
+
+	paths := make(summitPaths)
+
+	if p.grid.isSummit(from) {
+		paths[from] = 1
+	} else {
+		for _, neighbour := range p.grid.getCellNeighbours(from) {
+			if !p.grid.isStep(from, neighbour) {
+				continue
+			}
+
+			for summit, count := range p.count(neighbour) {
+				paths[summit] += count
+			}
+		}
+	}
+
+	p.cache[from] = paths
+
+	return paths
+}
+
+type SummitReport struct {
+	X     int `json:"x"`
+	Y     int `json:"y"`
+	Paths int `json:"paths"`
+}
+
+type TrailheadReport struct {
+	X       int            `json:"x"`
+	Y       int            `json:"y"`
+	Score   int            `json:"score"`
+	Rating  int            `json:"rating"`
+	Summits []SummitReport `json:"summits"`
+}
+
+// trailReports summarises every trailhead, with its score being the number
+// of summits it reaches and its rating the number of distinct trails.
+func (g *Grid) trailReports() []TrailheadReport {
+	counter := newPathCounter(g)
+	reports := make([]TrailheadReport, 0)
+
+	for _, head := range g.trailheads() {
+		report := TrailheadReport{
+			X:       head.x,
+			Y:       head.y,
+			Summits: make([]SummitReport, 0),
+		}
+
+		for summit, paths := range counter.count(head) {
+			report.Summits = append(report.Summits, SummitReport{summit.x, summit.y, paths})
+			report.Score++
+			report.Rating += paths
+		}
+
+		slices.SortFunc(report.Summits, func(a, b SummitReport) int {
+			if a.Y != b.Y {
+				return a.Y - b.Y
+			}
+
+			return a.X - b.X
+		})
+
+		reports = append(reports, report)
+	}
+
+	return reports
+}
+
+func writeTrailsText(w io.Writer, g *Grid, reports []TrailheadReport) error {
+	for _, report := range reports {
+		fmt.Fprintf(w, "Trailhead (%d,%d): score %d, rating %d\n", report.X, report.Y, report.Score, report.Rating)
+
+		for _, summit := range report.Summits {
+			fmt.Fprintf(w, "  summit (%d,%d): %d trail(s)\n", summit.X, summit.Y, summit.Paths)
+		}
+
+		for _, trail := range g.trails(g.cells[report.Y][report.X]) {
+			fmt.Fprintf(w, "  %s\n", trail)
+		}
+	}
+
+	return nil
+}
+
+func writeTrailsCSV(w io.Writer, reports []TrailheadReport) error {
+	out := csv.NewWriter(w)
+
+	if err := out.Write([]string{"trailhead_x", "trailhead_y", "summit_x", "summit_y", "paths"}); err != nil {
+		return err
+	}
+
+	for _, report := range reports {
+		for _, summit := range report.Summits {
+			err := out.Write([]string{
+				strconv.Itoa(report.X),
+				strconv.Itoa(report.Y),
+				strconv.Itoa(summit.X),
+				strconv.Itoa(summit.Y),
+				strconv.Itoa(summit.Paths),
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	out.Flush()
+
+	return out.Error()
+}
+
+func writeTrails(w io.Writer, input, format string) error {
+	grid, err := newGrid(input)
+	if err != nil {
+		return err
+	}
+
+	reports := grid.trailReports()
+
+	switch format {
+	case "text":
+		return writeTrailsText(w, grid, reports)
+	case "csv":
+		return writeTrailsCSV(w, reports)
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(reports)
+	default:
+		return fmt.Errorf("unsupported trails format: %q", format)
+	}
+}
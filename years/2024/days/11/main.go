@@ -0,0 +1,186 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"aoc/cmdline"
+	"aoc/inputs"
+)
+
+func logErr(err error) {
+	fmt.Printf("ERROR: %+v\n", err)
+
+	os.Exit(1)
+}
+
+func useSample(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	if args[0] == "sample" {
+		return true
+	}
+
+	return false
+}
+
+func getInput(args []string) (string, error) {
+	fileName := "input.txt"
+	if useSample(args) {
+		fileName = "sample.txt"
+	}
+
+	data, err := inputs.ReadFile(fileName)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Trim(string(data), "\n"), nil
+}
+
+var (
+	blinks     = flag.Uint64("blinks", 0, "blink this many times instead of running the parts")
+	showSeries = flag.Bool("series", false, "with --blinks, print the number of stones after every blink")
+	mod        = flag.Uint64("mod", 0, "with --blinks, count modulo this prime using the transition matrix, for huge blink counts")
+)
+
+// Beyond this the exact counts have thousands of digits, and blinking one at
+// a time takes too long, so --mod is needed.
+const maxExactBlinks = 10000
+
+func main() {
+	args, err := cmdline.Parse(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		logErr(err)
+	}
+
+	input, err := getInput(args)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	if *blinks > 0 {
+		if err := printBlinks(input); err != nil {
+			logErr(err)
+		}
+		return
+	}
+
+	p1, err := part1(input)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	fmt.Printf("Part 1: %d\n", p1)
+
+	p2, err := part2(input)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	fmt.Printf("Part 2: %d\n", p2)
+}
+
+var cache = make(map[int][]int)
+
+func getTransformation(stone int) ([]int, error) {
+	if result, ok := cache[stone]; ok {
+		return result, nil
+	}
+
+	result, err := transformStone(stone)
+	if err != nil {
+		return nil, err
+	}
+
+	cache[stone] = result
+	return result, nil
+}
+
+func getStones(input string) (map[int]int, error) {
+	stones := make(map[int]int)
+
+	for _, stoneValue := range strings.Split(strings.Trim(input, " "), " ") {
+		value, err := strconv.Atoi(stoneValue)
+		if err != nil {
+			return nil, err
+		}
+
+		stones[value]++
+	}
+
+	return stones, nil
+}
+
+func part1(input string) (int, error) {
+	return countAfter(input, 25)
+}
+
+func part2(input string) (int, error) {
+	return countAfter(input, 75)
+}
+
+// printBlinks reports the stones after --blinks, or after every blink up to it
+// with --series.
+func printBlinks(input string) error {
+	if *blinks > math.MaxInt {
+		return fmt.Errorf("--blinks can be at most %d", math.MaxInt)
+	}
+
+	stones, err := getStones(input)
+	if err != nil {
+		return err
+	}
+
+	if *mod != 0 {
+		if *showSeries {
+			return errors.New("--series can't be used with --mod")
+		}
+
+		count, err := countAfterMod(stones, *blinks, modulus(*mod))
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Blink %d: %d (mod %d)\n", *blinks, count, *mod)
+
+		return nil
+	}
+
+	n := int(*blinks)
+	if n > maxExactBlinks {
+		return fmt.Errorf("--blinks %d is too many to count exactly, pass --mod to count modulo a prime", n)
+	}
+
+	if !*showSeries {
+		total, err := blinkTotal(stones, n)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Blink %d: %d\n", n, total)
+
+		return nil
+	}
+
+	series, err := blinkSeries(stones, n)
+	if err != nil {
+		return err
+	}
+
+	for idx, count := range series {
+		fmt.Printf("Blink %d: %d\n", idx+1, count)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import "testing"
+
+const sample = "125 17"
+
+func TestCountAfterModAgreesWithIterating(t *testing.T) {
+	stones, err := getStones(sample)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, blinks := range []int{25, 75} {
+		iterated, err := countAfter(sample, blinks)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		viaMatrix, err := countAfterMod(stones, uint64(blinks), defaultModulus)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if uint64(iterated) != viaMatrix {
+			t.Errorf("%d blinks: iterating gives %d, matrix gives %d", blinks, iterated, viaMatrix)
+		}
+	}
+}
+
+func TestCountAfterModSmallPrime(t *testing.T) {
+	stones, err := getStones(sample)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := countAfterMod(stones, 100, 1000000007)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 620470694 {
+		t.Errorf("100 blinks mod 1000000007: got %d, expected 620470694", count)
+	}
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// The largest prime that fits the arithmetic below, big enough that the
+// answers to both parts come out exactly.
+const defaultModulus = 1<<61 - 1
+
+type modulus uint64
+
+func (m modulus) add(a, b uint64) uint64 {
+	sum := a + b
+	if sum >= uint64(m) || sum < a {
+		sum -= uint64(m)
+	}
+
+	return sum
+}
+
+func (m modulus) sub(a, b uint64) uint64 {
+	if a >= b {
+		return a - b
+	}
+
+	return uint64(m) - b + a
+}
+
+func (m modulus) mul(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+
+	return bits.Rem64(hi, lo, uint64(m))
+}
+
+func (m modulus) pow(base, exp uint64) uint64 {
+	result := uint64(1)
+
+	for ; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			result = m.mul(result, base)
+		}
+
+		base = m.mul(base, base)
+	}
+
+	return result
+}
+
+// Only valid because the modulus is prime
+func (m modulus) inverse(a uint64) uint64 {
+	return m.pow(a, uint64(m)-2)
+}
+
+// transitionMatrix is the sparse matrix of which stone values turn into which
+// on a blink, over every value that can be reached from the input. Row i
+// lists the columns with a 1 in them, a value appears twice if a stone splits
+// into two equal halves.
+type transitionMatrix struct {
+	values []int
+	index  map[int]int
+	rows   [][]int
+}
+
+// newTransitionMatrix discovers every stone value reachable from the input.
+// The set is finite, since large values always split into smaller ones.
+func newTransitionMatrix(stones map[int]int) (*transitionMatrix, error) {
+	t := &transitionMatrix{
+		values: make([]int, 0),
+		index:  make(map[int]int),
+		rows:   make([][]int, 0),
+	}
+
+	queue := make([]int, 0, len(stones))
+	for stone := range stones {
+		t.add(stone)
+		queue = append(queue, stone)
+	}
+
+	for len(queue) > 0 {
+		stone := queue[0]
+		queue = queue[1:]
+
+		transformed, err := getTransformation(stone)
+		if err != nil {
+			return nil, err
+		}
+
+		row := make([]int, 0, len(transformed))
+		for _, next := range transformed {
+			if _, ok := t.index[next]; !ok {
+				t.add(next)
+				queue = append(queue, next)
+			}
+
+			row = append(row, t.index[next])
+		}
+
+		t.rows[t.index[stone]] = row
+	}
+
+	return t, nil
+}
+
+func (t *transitionMatrix) add(stone int) {
+	t.index[stone] = len(t.values)
+	t.values = append(t.values, stone)
+	t.rows = append(t.rows, nil)
+}
+
+// step multiplies the row vector of stone counts by the matrix, which is a
+// single blink.
+func (t *transitionMatrix) step(counts []uint64, m modulus) []uint64 {
+	next := make([]uint64, len(counts))
+
+	for from, count := range counts {
+		if count == 0 {
+			continue
+		}
+
+		for _, to := range t.rows[from] {
+			next[to] = m.add(next[to], count)
+		}
+	}
+
+	return next
+}
+
+// berlekampMassey finds the shortest linear recurrence the sequence follows,
+// returning c where seq[n] = c[0]*seq[n-1] + c[1]*seq[n-2] + ...
+func berlekampMassey(seq []uint64, m modulus) []uint64 {
+	current := make([]uint64, 0)
+	previous := make([]uint64, 0)
+
+	lastFail, lastDelta := -1, uint64(0)
+
+	for n := range seq {
+		delta := seq[n]
+		for i, c := range current {
+			delta = m.sub(delta, m.mul(c, seq[n-1-i]))
+		}
+
+		if delta == 0 {
+			continue
+		}
+
+		if lastFail == -1 {
+			current = make([]uint64, n+1)
+			lastFail, lastDelta = n, delta
+
+			continue
+		}
+
+		// current -= (delta / lastDelta) * x^(n-lastFail-1) * (1 - previous)
+		scale := m.mul(delta, m.inverse(lastDelta))
+		shift := n - lastFail - 1
+
+		correction := make([]uint64, shift+1+len(previous))
+		correction[shift] = scale
+		for i, p := range previous {
+			correction[shift+1+i] = m.sub(0, m.mul(scale, p))
+		}
+
+		updated := make([]uint64, max(len(current), len(correction)))
+		copy(updated, current)
+		for i, c := range correction {
+			updated[i] = m.add(updated[i], c)
+		}
+
+		if n-lastFail+len(previous) >= len(current) {
+			previous = current
+			lastFail, lastDelta = n, delta
+		}
+
+		current = updated
+	}
+
+	return current
+}
+
+// polyMulMod multiplies two polynomials, reducing by the recurrence's
+// characteristic polynomial x^d - c[0]*x^(d-1) - ... - c[d-1].
+func polyMulMod(a, b, recurrence []uint64, m modulus) []uint64 {
+	d := len(recurrence)
+	product := make([]uint64, 2*d)
+
+	for i, x := range a {
+		if x == 0 {
+			continue
+		}
+
+		for j, y := range b {
+			product[i+j] = m.add(product[i+j], m.mul(x, y))
+		}
+	}
+
+	for i := 2*d - 1; i >= d; i-- {
+		if product[i] == 0 {
+			continue
+		}
+
+		for j, c := range recurrence {
+			product[i-1-j] = m.add(product[i-1-j], m.mul(product[i], c))
+		}
+	}
+
+	return product[:d]
+}
+
+// countAfterMod works out the number of stones after any number of blinks,
+// modulo a prime, by raising the transition matrix to that power.
+//
+// Squaring the matrix itself is hopeless, as its powers are dense and it can
+// have thousands of rows. Instead, by Cayley-Hamilton, M^n can be reduced to
+// a polynomial in M with fewer terms than M has rows. The polynomial is found
+// with Berlekamp-Massey on the first few totals, then x^n is reduced by it
+// with exponentiation by squaring, and the totals are combined with the
+// resulting coefficients.
+func countAfterMod(stones map[int]int, blinks uint64, m modulus) (uint64, error) {
+	if !big.NewInt(0).SetUint64(uint64(m)).ProbablyPrime(20) {
+		return 0, fmt.Errorf("modulus %d is not prime", m)
+	}
+
+	matrix, err := newTransitionMatrix(stones)
+	if err != nil {
+		return 0, err
+	}
+
+	counts := make([]uint64, len(matrix.values))
+	for stone, count := range stones {
+		counts[matrix.index[stone]] = uint64(count) % uint64(m)
+	}
+
+	// The recurrence can't be longer than the number of stone values, so
+	// twice that many totals is enough to pin it down.
+	totals := make([]uint64, 0, 2*len(counts)+2)
+	for len(totals) < cap(totals) {
+		total := uint64(0)
+		for _, count := range counts {
+			total = m.add(total, count)
+		}
+
+		totals = append(totals, total)
+		if uint64(len(totals)) > blinks {
+			return totals[blinks], nil
+		}
+
+		counts = matrix.step(counts, m)
+	}
+
+	recurrence := berlekampMassey(totals, m)
+	if len(recurrence) == 0 {
+		return 0, nil
+	}
+
+	if 2*len(recurrence) > len(totals) {
+		return 0, errors.New("recurrence is longer than the totals can determine")
+	}
+
+	// x^blinks mod the characteristic polynomial, as coefficients of x^0..x^(d-1)
+	result := make([]uint64, len(recurrence))
+	result[0] = 1
+
+	base := make([]uint64, len(recurrence))
+	if len(recurrence) == 1 {
+		base[0] = recurrence[0]
+	} else {
+		base[1] = 1
+	}
+
+	for exp := blinks; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			result = polyMulMod(result, base, recurrence, m)
+		}
+
+		base = polyMulMod(base, base, recurrence, m)
+	}
+
+	total := uint64(0)
+	for i, coefficient := range result {
+		total = m.add(total, m.mul(coefficient, totals[i]))
+	}
+
+	return total, nil
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// Rule changes a stone when it applies. Only the first rule that applies to
+// a stone is used, so the order they're declared in matters.
+type Rule struct {
+	name      string
+	applies   func(stone int) bool
+	transform func(stone int) ([]int, error)
+}
+
+var rules = []Rule{
+	{
+		name:    "0 becomes 1",
+		applies: func(stone int) bool { return stone == 0 },
+		transform: func(stone int) ([]int, error) {
+			return []int{1}, nil
+		},
+	},
+	{
+		name: "even number of digits splits in two",
+		applies: func(stone int) bool {
+			return len(strconv.Itoa(stone))%2 == 0
+		},
+		transform: func(stone int) ([]int, error) {
+			digits := strconv.Itoa(stone)
+
+			left, err := strconv.Atoi(digits[:len(digits)/2])
+			if err != nil {
+				return nil, err
+			}
+
+			right, err := strconv.Atoi(digits[len(digits)/2:])
+			if err != nil {
+				return nil, err
+			}
+
+			return []int{left, right}, nil
+		},
+	},
+	{
+		name:    "anything else is multiplied by 2024",
+		applies: func(stone int) bool { return true },
+		transform: func(stone int) ([]int, error) {
+			if stone > math.MaxInt/2024 {
+				return nil, fmt.Errorf("stone %d is too big to multiply by 2024", stone)
+			}
+
+			return []int{stone * 2024}, nil
+		},
+	},
+}
+
+func transformStone(stone int) ([]int, error) {
+	for _, rule := range rules {
+		if rule.applies(stone) {
+			return rule.transform(stone)
+		}
+	}
+
+	return nil, fmt.Errorf("no rule applies to stone %d", stone)
+}
+
+// blinker counts stones by value, using plain ints until a count would
+// overflow and switching to big ints from then on.
+type blinker struct {
+	counts    map[int]int
+	bigCounts map[int]*big.Int
+}
+
+func newBlinker(stones map[int]int) *blinker {
+	return &blinker{counts: stones}
+}
+
+func (b *blinker) toBig() {
+	b.bigCounts = make(map[int]*big.Int, len(b.counts))
+
+	for stone, count := range b.counts {
+		b.bigCounts[stone] = big.NewInt(int64(count))
+	}
+
+	b.counts = nil
+}
+
+// blinkInts returns false, leaving the counts untouched, if any count would
+// overflow.
+func (b *blinker) blinkInts() (bool, error) {
+	newStones := make(map[int]int)
+
+	for stone, value := range b.counts {
+		transformedStones, err := getTransformation(stone)
+		if err != nil {
+			return false, err
+		}
+
+		for _, newStone := range transformedStones {
+			if newStones[newStone] > math.MaxInt-value {
+				return false, nil
+			}
+
+			newStones[newStone] += value
+		}
+	}
+
+	b.counts = newStones
+
+	return true, nil
+}
+
+func (b *blinker) blinkBig() error {
+	newStones := make(map[int]*big.Int)
+
+	for stone, value := range b.bigCounts {
+		transformedStones, err := getTransformation(stone)
+		if err != nil {
+			return err
+		}
+
+		for _, newStone := range transformedStones {
+			count, ok := newStones[newStone]
+			if !ok {
+				count = new(big.Int)
+				newStones[newStone] = count
+			}
+
+			count.Add(count, value)
+		}
+	}
+
+	b.bigCounts = newStones
+
+	return nil
+}
+
+func (b *blinker) blink() error {
+	if b.bigCounts == nil {
+		ok, err := b.blinkInts()
+		if err != nil || ok {
+			return err
+		}
+
+		b.toBig()
+	}
+
+	return b.blinkBig()
+}
+
+func (b *blinker) total() *big.Int {
+	result := new(big.Int)
+
+	if b.bigCounts != nil {
+		for _, count := range b.bigCounts {
+			result.Add(result, count)
+		}
+
+		return result
+	}
+
+	// Summed as a big int, as the total can overflow even when no count does
+	for _, count := range b.counts {
+		result.Add(result, big.NewInt(int64(count)))
+	}
+
+	return result
+}
+
+// blinkTotal blinks n times, returning the number of stones at the end
+func blinkTotal(stones map[int]int, n int) (*big.Int, error) {
+	b := newBlinker(stones)
+
+	for count := 0; count < n; count++ {
+		if err := b.blink(); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.total(), nil
+}
+
+// blinkSeries blinks n times, returning the number of stones after each blink
+func blinkSeries(stones map[int]int, n int) ([]*big.Int, error) {
+	b := newBlinker(stones)
+	series := make([]*big.Int, 0, n)
+
+	for count := 0; count < n; count++ {
+		if err := b.blink(); err != nil {
+			return nil, err
+		}
+
+		series = append(series, b.total())
+	}
+
+	return series, nil
+}
+
+func countAfter(input string, blinks int) (int, error) {
+	stones, err := getStones(input)
+	if err != nil {
+		return -1, err
+	}
+
+	result, err := blinkTotal(stones, blinks)
+	if err != nil {
+		return -1, err
+	}
+
+	if !result.IsInt64() {
+		return -1, fmt.Errorf("%d stones after %d blinks is too many for an int", result, blinks)
+	}
+
+	return int(result.Int64()), nil
+}
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
+
+	"aoc/cmdline"
+	"aoc/inputs"
 )
 
 func logErr(err error) {
@@ -31,7 +35,7 @@ func getInput(args []string) (string, error) {
 		fileName = "sample.txt"
 	}
 
-	data, err := os.ReadFile(fileName)
+	data, err := inputs.ReadFile(fileName)
 	if err != nil {
 		return "", err
 	}
@@ -39,8 +43,20 @@ func getInput(args []string) (string, error) {
 	return strings.Trim(string(data), "\n"), nil
 }
 
+var (
+	minStep  = flag.Int("min-step", 1, "smallest allowed difference between adjacent levels")
+	maxStep  = flag.Int("max-step", 3, "largest allowed difference between adjacent levels")
+	removals = flag.Int("removals", 1, "how many levels part 2 may remove from a report")
+	explain  = flag.Bool("explain", false, "print the part 2 verdict for every report")
+)
+
 func main() {
-	input, err := getInput(os.Args[1:])
+	args, err := cmdline.Parse(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		logErr(err)
+	}
+
+	input, err := getInput(args)
 
 	if err != nil {
 		logErr(err)
@@ -63,17 +79,9 @@ func main() {
 	fmt.Printf("Part 2: %d\n", p2)
 }
 
-func dist(a, b int) int {
-	if a > b {
-		return a - b
-	}
-	return b - a
-}
-
 type report struct {
 	idx    int
 	levels []int
-	isDesc bool
 }
 
 func createReport(idx int, line string) (*report, error) {
@@ -92,63 +100,13 @@ func createReport(idx int, line string) (*report, error) {
 		levels = append(levels, val)
 	}
 
-	isDesc := levels[0] > levels[len(levels)-1]
-
 	return &report{
 		idx,
 		levels,
-		isDesc,
 	}, nil
 }
 
-func checkLevels(levels []int, isDesc bool) (bool, int) {
-	prevLevel := levels[0]
-
-	for idx, level := range levels[1:] {
-		distance := dist(level, prevLevel)
-		if distance > 3 || distance < 1 {
-			return false, idx + 1
-		}
-
-		if (isDesc && level > prevLevel) || (!isDesc && level < prevLevel) {
-			return false, idx + 1
-		}
-
-		prevLevel = level
-	}
-
-	return true, -1
-}
-
-func copyAndRemove(s []int, i int) []int {
-	new := make([]int, len(s))
-
-	copy(new, s)
-
-	return append(new[:i], new[i+1:]...)
-}
-
-func (r report) isSafeWithTolerance() bool {
-	safe, idx := checkLevels(r.levels, r.isDesc)
-	if safe {
-		return safe
-	}
-
-	rightSide := copyAndRemove(r.levels, idx)
-	if s, _ := checkLevels(rightSide, r.isDesc); s {
-		return true
-	}
-
-	leftSide := copyAndRemove(r.levels, idx-1)
-	if s, _ := checkLevels(leftSide, r.isDesc); s {
-		return true
-	}
-
-	return false
-}
-
-// Answer: 486
-func part1(input string) (int, error) {
+func countSafe(input string, rules Rules) (int, error) {
 	safe := 0
 
 	for idx, line := range strings.Split(input, "\n") {
@@ -158,29 +116,25 @@ func part1(input string) (int, error) {
 			return -1, err
 		}
 
-		if isSafe, _ := checkLevels(rep.levels, rep.isDesc); isSafe {
+		verdict := rules.check(rep.levels)
+		if verdict.safe {
 			safe += 1
 		}
+
+		if *explain && rules.removals > 0 {
+			fmt.Printf("Report %d %v: %s\n", rep.idx+1, rep.levels, verdict.explain(rep.levels))
+		}
 	}
 
 	return safe, nil
 }
 
+// Answer: 486
+func part1(input string) (int, error) {
+	return countSafe(input, Rules{*minStep, *maxStep, 0})
+}
+
 // Answer: 540
 func part2(input string) (int, error) {
-	safe := 0
-
-	for idx, line := range strings.Split(input, "\n") {
-		rep, err := createReport(idx, line)
-
-		if err != nil {
-			return -1, nil
-		}
-
-		if rep.isSafeWithTolerance() {
-			safe += 1
-		}
-	}
-
-	return safe, nil
+	return countSafe(input, Rules{*minStep, *maxStep, *removals})
 }
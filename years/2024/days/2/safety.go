@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rules decide whether a report is safe: every step between kept levels must
+// be between minStep and maxStep in the same direction, after dropping at
+// most removals levels.
+type Rules struct {
+	minStep, maxStep, removals int
+}
+
+// Verdict is the outcome of checking one report
+type Verdict struct {
+	safe, descending bool
+	// Indexes of the levels that had to be removed
+	removed []int
+}
+
+func (r Rules) validStep(from, to int, descending bool) bool {
+	step := to - from
+	if descending {
+		step = -step
+	}
+
+	return step >= r.minStep && step <= r.maxStep
+}
+
+// checkDirection finds the fewest removals that leave the levels valid in the
+// given direction.
+//
+// reachable[i][j] is true when levels[i] can be kept with exactly j levels
+// removed before it. Kept levels can only be up to removals apart, so each
+// state looks back at most removals+1 levels, keeping this linear in the
+// number of levels for a fixed tolerance.
+func (r Rules) checkDirection(levels []int, descending bool) Verdict {
+	n := len(levels)
+	k := r.removals
+
+	reachable := make([][]bool, n)
+	// The index of the previously kept level for each state, -1 for the first
+	previous := make([][]int, n)
+
+	for i := range levels {
+		reachable[i] = make([]bool, k+1)
+		previous[i] = make([]int, k+1)
+
+		// Keeping i as the first level means everything before it was removed
+		if i <= k {
+			reachable[i][i] = true
+			previous[i][i] = -1
+		}
+
+		for j := 0; j <= k; j++ {
+			for gap := 0; gap <= j && i-1-gap >= 0; gap++ {
+				prev := i - 1 - gap
+
+				if reachable[i][j] || !reachable[prev][j-gap] {
+					continue
+				}
+
+				if r.validStep(levels[prev], levels[i], descending) {
+					reachable[i][j] = true
+					previous[i][j] = prev
+				}
+			}
+		}
+	}
+
+	best, bestRemoved := -1, -1
+	for i := n - 1; i >= 0 && n-1-i <= k; i-- {
+		for j := 0; j+n-1-i <= k; j++ {
+			if reachable[i][j] && (best == -1 || j+n-1-i < bestRemoved) {
+				best, bestRemoved = i, j+n-1-i
+			}
+		}
+	}
+
+	if best == -1 {
+		return Verdict{safe: false, descending: descending}
+	}
+
+	kept := make([]bool, n)
+	for i, j := best, bestRemoved-(n-1-best); i != -1; {
+		kept[i] = true
+
+		prev := previous[i][j]
+		if prev != -1 {
+			j -= i - 1 - prev
+		}
+
+		i = prev
+	}
+
+	removed := make([]int, 0, bestRemoved)
+	for idx, keep := range kept {
+		if !keep {
+			removed = append(removed, idx)
+		}
+	}
+
+	return Verdict{safe: true, descending: descending, removed: removed}
+}
+
+// check tries both directions and keeps whichever needs fewer removals
+func (r Rules) check(levels []int) Verdict {
+	if len(levels) == 0 {
+		return Verdict{safe: true}
+	}
+
+	asc := r.checkDirection(levels, false)
+	desc := r.checkDirection(levels, true)
+
+	if !desc.safe || (asc.safe && len(asc.removed) <= len(desc.removed)) {
+		return asc
+	}
+
+	return desc
+}
+
+func (v Verdict) explain(levels []int) string {
+	if !v.safe {
+		return "unsafe"
+	}
+
+	direction := "increasing"
+	if v.descending {
+		direction = "decreasing"
+	}
+
+	if len(v.removed) == 0 {
+		return fmt.Sprintf("safe, %s", direction)
+	}
+
+	removed := make([]string, len(v.removed))
+	for idx, level := range v.removed {
+		removed[idx] = fmt.Sprintf("level %d (%d)", level+1, levels[level])
+	}
+
+	return fmt.Sprintf("safe, %s after removing %s", direction, strings.Join(removed, ", "))
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Instruction is anything the corrupted memory can contain, written as
+// name(arg,arg,...) with each argument being 1 to 3 digits.
+type Instruction struct {
+	name  string
+	arity int
+	exec  func(m *Machine, args []int)
+}
+
+// New instructions only need adding here, the lexer and interpreter pick
+// them up from this table.
+var instructions = []*Instruction{
+	{
+		name:  "mul",
+		arity: 2,
+		exec: func(m *Machine, args []int) {
+			if m.enabled || !m.conditional {
+				m.result += args[0] * args[1]
+			}
+		},
+	},
+	{
+		name:  "do",
+		arity: 0,
+		exec: func(m *Machine, args []int) {
+			m.enabled = true
+		},
+	},
+	{
+		name:  "don't",
+		arity: 0,
+		exec: func(m *Machine, args []int) {
+			m.enabled = false
+		},
+	},
+}
+
+const maxDigits = 3
+
+type Token struct {
+	instruction *Instruction
+	pos         int
+	args        []int
+}
+
+func (t Token) String() string {
+	args := make([]string, len(t.args))
+	for idx, arg := range t.args {
+		args[idx] = fmt.Sprint(arg)
+	}
+
+	return fmt.Sprintf("%d: %s(%s)", t.pos, t.instruction.name, strings.Join(args, ","))
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// readArgs parses "(a,b,...)" starting at pos, returning the arguments and
+// the position just after the closing bracket, or -1 if it is malformed.
+func readArgs(input string, pos, arity int) ([]int, int) {
+	if pos >= len(input) || input[pos] != '(' {
+		return nil, -1
+	}
+	pos++
+
+	args := make([]int, 0, arity)
+
+	for idx := 0; idx < arity; idx++ {
+		if idx > 0 {
+			if pos >= len(input) || input[pos] != ',' {
+				return nil, -1
+			}
+			pos++
+		}
+
+		value, digits := 0, 0
+		for pos < len(input) && isDigit(input[pos]) {
+			value = value*10 + int(input[pos]-'0')
+			digits++
+			pos++
+		}
+
+		if digits == 0 || digits > maxDigits {
+			return nil, -1
+		}
+
+		args = append(args, value)
+	}
+
+	if pos >= len(input) || input[pos] != ')' {
+		return nil, -1
+	}
+
+	return args, pos + 1
+}
+
+// lex scans the corrupted memory once, returning every well formed
+// instruction in the order they appear. Anything else is skipped.
+func lex(input string) []Token {
+	tokens := make([]Token, 0)
+	pos := 0
+
+	for pos < len(input) {
+		matched := false
+
+		for _, instruction := range instructions {
+			if !strings.HasPrefix(input[pos:], instruction.name) {
+				continue
+			}
+
+			args, end := readArgs(input, pos+len(instruction.name), instruction.arity)
+			if end == -1 {
+				continue
+			}
+
+			tokens = append(tokens, Token{instruction, pos, args})
+			pos = end
+			matched = true
+
+			break
+		}
+
+		if !matched {
+			pos++
+		}
+	}
+
+	return tokens
+}
+
+// Machine evaluates a token stream. When conditional is false the do() and
+// don't() toggles are still tracked, but every mul() counts.
+type Machine struct {
+	enabled, conditional bool
+	result               int
+}
+
+func newMachine(conditional bool) *Machine {
+	return &Machine{
+		enabled:     true,
+		conditional: conditional,
+	}
+}
+
+func (m *Machine) run(tokens []Token) int {
+	for _, token := range tokens {
+		token.instruction.exec(m, token.args)
+	}
+
+	return m.result
+}
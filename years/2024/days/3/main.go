@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"aoc/cmdline"
+	"aoc/inputs"
+)
+
+func logErr(err error) {
+	fmt.Printf("ERROR: %+v\n", err)
+
+	os.Exit(1)
+}
+
+func useSample(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	if args[0] == "sample" {
+		return true
+	}
+
+	return false
+}
+
+func getInput(args []string) (string, error) {
+	fileName := "input.txt"
+	if useSample(args) {
+		fileName = "sample.txt"
+	}
+
+	data, err := inputs.ReadFile(fileName)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Trim(string(data), "\n"), nil
+}
+
+var showTokens = flag.Bool("tokens", false, "print every instruction found, with its position")
+
+func main() {
+	args, err := cmdline.Parse(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		logErr(err)
+	}
+
+	input, err := getInput(args)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	if *showTokens {
+		for _, token := range lex(input) {
+			fmt.Println(token)
+		}
+	}
+
+	p1, err := part1(input)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	fmt.Printf("Part 1: %d\n", p1)
+
+	p2, err := part2(input)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	fmt.Printf("Part 2: %d\n", p2)
+}
+
+func part1(input string) (int, error) {
+	return newMachine(false).run(lex(input)), nil
+}
+
+func part2(input string) (int, error) {
+	return newMachine(true).run(lex(input)), nil
+}
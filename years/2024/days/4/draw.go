@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"aoc/render"
+)
+
+var letterColours = map[string]color.Color{
+	"X": color.RGBA{0xe0, 0x4f, 0x4f, 0xff},
+	"M": color.RGBA{0xe0, 0xb0, 0x3f, 0xff},
+	"A": color.RGBA{0x4f, 0xc0, 0x6f, 0xff},
+	"S": color.RGBA{0x4f, 0x8f, 0xe0, 0xff},
+}
+
+var unmatched = color.RGBA{0x2a, 0x2a, 0x3a, 0xff}
+
+// renderMatches writes the grid with every cell that is part of a match for
+// the given part coloured by its letter, and everything else dimmed.
+func renderMatches(input, path string, part int) error {
+	grid := createGrid(input)
+
+	var matches []Match
+	var err error
+
+	switch part {
+	case 1:
+		matches, err = xmasMatches(grid)
+	case 2:
+		matches, err = crossMatches(grid)
+	default:
+		return fmt.Errorf("invalid part: %d", part)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	matched := make(map[coord]bool)
+	for _, match := range matches {
+		for _, cell := range match {
+			matched[coord{cell.x, cell.y}] = true
+		}
+	}
+
+	image := render.Grid[Cell]{
+		Cells: grid.cells,
+		Colour: func(c Cell) color.Color {
+			if !matched[coord{c.x, c.y}] {
+				return unmatched
+			}
+
+			return letterColours[c.char]
+		},
+		Label: func(c Cell) string {
+			return c.char
+		},
+		Scale: 16,
+	}
+
+	return image.Save(path)
+}
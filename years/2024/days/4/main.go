@@ -0,0 +1,188 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"aoc/cmdline"
+	"aoc/inputs"
+)
+
+func logErr(err error) {
+	fmt.Printf("ERROR: %+v\n", err)
+
+	os.Exit(1)
+}
+
+func useSample(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	if args[0] == "sample" {
+		return true
+	}
+
+	return false
+}
+
+func getInput(args []string) (string, error) {
+	fileName := "input.txt"
+	if useSample(args) {
+		fileName = "sample.txt"
+	}
+
+	data, err := inputs.ReadFile(fileName)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Trim(string(data), "\n"), nil
+}
+
+var (
+	renderPath = flag.String("render", "", "write the grid with every match highlighted to this .png or .svg file")
+	renderPart = flag.Int("part", 1, "which part's matches --render highlights")
+	word       = flag.String("word", "", "search for this word in all 8 directions, ? matches any character")
+	maskPath   = flag.String("mask", "", "search for the pattern drawn in this file, in every rotation and reflection")
+)
+
+// search looks for the --word or --mask pattern and lists every match
+func search(input string) error {
+	grid := createGrid(input)
+
+	var matches []Match
+	var err error
+
+	if *word != "" {
+		matches, err = findWord(grid, *word)
+	} else {
+		var mask []byte
+
+		mask, err = os.ReadFile(*maskPath)
+		if err != nil {
+			return err
+		}
+
+		matches, err = findMask(grid, string(mask))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, match := range matches {
+		fmt.Println(match)
+	}
+
+	fmt.Printf("Matches: %d\n", len(matches))
+
+	return nil
+}
+
+func main() {
+	args, err := cmdline.Parse(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		logErr(err)
+	}
+
+	input, err := getInput(args)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	if *word != "" || *maskPath != "" {
+		if err := search(input); err != nil {
+			logErr(err)
+		}
+		return
+	}
+
+	if *renderPath != "" {
+		if err := renderMatches(input, *renderPath, *renderPart); err != nil {
+			logErr(err)
+		}
+	}
+
+	p1, err := part1(input)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	fmt.Printf("Part 1: %d\n", p1)
+
+	p2, err := part2(input)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	fmt.Printf("Part 2: %d\n", p2)
+}
+
+type Cell struct {
+	x, y int
+	char string
+}
+
+type Grid struct {
+	lineLength int
+	cells      [][]Cell
+}
+
+func createGrid(input string) Grid {
+	lineLength := strings.Index(input, "\n")
+	cells := make([][]Cell, 0)
+
+	for y, line := range strings.Split(input, "\n") {
+		row := make([]Cell, 0)
+
+		for x, char := range strings.Split(line, "") {
+			row = append(row, Cell{x, y, char})
+		}
+
+		cells = append(cells, row)
+	}
+
+	return Grid{lineLength, cells}
+}
+
+type coord struct{ x, y int }
+
+const xmasMask = `
+M.S
+.A.
+M.S
+`
+
+// xmasMatches returns the cells of every XMAS found
+func xmasMatches(grid Grid) ([]Match, error) {
+	return findWord(grid, "XMAS")
+}
+
+// crossMatches returns the cells of every X-MAS found
+func crossMatches(grid Grid) ([]Match, error) {
+	return findMask(grid, xmasMask)
+}
+
+func part1(input string) (int, error) {
+	matches, err := xmasMatches(createGrid(input))
+	if err != nil {
+		return -1, err
+	}
+
+	return len(matches), nil
+}
+
+func part2(input string) (int, error) {
+	matches, err := crossMatches(createGrid(input))
+	if err != nil {
+		return -1, err
+	}
+
+	return len(matches), nil
+}
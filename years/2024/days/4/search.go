@@ -0,0 +1,236 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// Wildcard matches any character, in both words and masks
+	Wildcard = "?"
+	// Blank marks a cell in a mask which is not part of the pattern
+	Blank = "."
+)
+
+// Every direction a word can be read in, starting to the right and going
+// clockwise.
+var directions = []coord{
+	{1, 0}, {1, 1}, {0, 1}, {-1, 1},
+	{-1, 0}, {-1, -1}, {0, -1}, {1, -1},
+}
+
+type patternCell struct {
+	offset coord
+	char   string
+}
+
+// Pattern is a set of characters at fixed offsets from an anchor, the first
+// cell is always the anchor at {0, 0}.
+type Pattern []patternCell
+
+// Match holds the grid cells a pattern was found on, in pattern order
+type Match []Cell
+
+// normalise shifts the pattern so its top left corner sits on {0, 0} and
+// sorts the cells, so equal shapes compare equal.
+func (p Pattern) normalise() Pattern {
+	minX, minY := p[0].offset.x, p[0].offset.y
+
+	for _, cell := range p {
+		minX = min(minX, cell.offset.x)
+		minY = min(minY, cell.offset.y)
+	}
+
+	result := make(Pattern, len(p))
+	for idx, cell := range p {
+		result[idx] = patternCell{coord{cell.offset.x - minX, cell.offset.y - minY}, cell.char}
+	}
+
+	slices.SortFunc(result, func(a, b patternCell) int {
+		if a.offset.y != b.offset.y {
+			return a.offset.y - b.offset.y
+		}
+
+		return a.offset.x - b.offset.x
+	})
+
+	return result
+}
+
+func (p Pattern) transform(f func(coord) coord) Pattern {
+	result := make(Pattern, len(p))
+
+	for idx, cell := range p {
+		result[idx] = patternCell{f(cell.offset), cell.char}
+	}
+
+	return result
+}
+
+// dedupe drops any pattern with the same shape and characters as an earlier one
+func dedupe(patterns []Pattern) []Pattern {
+	result := make([]Pattern, 0, len(patterns))
+	seen := make([]Pattern, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		normalised := pattern.normalise()
+
+		if slices.ContainsFunc(seen, func(o Pattern) bool { return slices.Equal(o, normalised) }) {
+			continue
+		}
+
+		seen = append(seen, normalised)
+		result = append(result, pattern)
+	}
+
+	return result
+}
+
+// wordPatterns returns the word laid out in each of the 8 directions. Words
+// that read the same both ways, like "ABA", only produce one pattern per line
+// so each occurrence is found once.
+func wordPatterns(word string) ([]Pattern, error) {
+	if len(word) == 0 {
+		return nil, errors.New("empty word")
+	}
+
+	chars := strings.Split(word, "")
+	patterns := make([]Pattern, 0, len(directions))
+
+	for _, dir := range directions {
+		pattern := make(Pattern, len(chars))
+
+		for idx, char := range chars {
+			pattern[idx] = patternCell{coord{dir.x * idx, dir.y * idx}, char}
+		}
+
+		patterns = append(patterns, pattern)
+	}
+
+	return dedupe(patterns), nil
+}
+
+// parseMask reads a pattern drawn as text, one row per line. Blank cells are
+// left out and the first remaining cell becomes the anchor.
+func parseMask(mask string) (Pattern, error) {
+	pattern := make(Pattern, 0)
+	var anchor *coord
+
+	for y, line := range strings.Split(strings.Trim(mask, "\n"), "\n") {
+		for x, char := range strings.Split(line, "") {
+			if char == Blank || char == " " {
+				continue
+			}
+
+			if anchor == nil {
+				anchor = &coord{x, y}
+			}
+
+			pattern = append(pattern, patternCell{coord{x - anchor.x, y - anchor.y}, char})
+		}
+	}
+
+	if len(pattern) == 0 {
+		return nil, fmt.Errorf("mask has no cells: %q", mask)
+	}
+
+	return pattern, nil
+}
+
+// maskPatterns returns every distinct rotation and reflection of a mask
+func maskPatterns(mask string) ([]Pattern, error) {
+	pattern, err := parseMask(mask)
+	if err != nil {
+		return nil, err
+	}
+
+	rotate := func(c coord) coord { return coord{-c.y, c.x} }
+	reflect := func(c coord) coord { return coord{-c.x, c.y} }
+
+	patterns := make([]Pattern, 0, 8)
+
+	for _, base := range []Pattern{pattern, pattern.transform(reflect)} {
+		for turn := 0; turn < 4; turn++ {
+			patterns = append(patterns, base)
+			base = base.transform(rotate)
+		}
+	}
+
+	return dedupe(patterns), nil
+}
+
+func (g Grid) get(x, y int) *Cell {
+	if y < 0 || y >= len(g.cells) || x < 0 || x >= len(g.cells[y]) {
+		return nil
+	}
+
+	return &g.cells[y][x]
+}
+
+// matchAt checks a single pattern anchored on the given cell
+func (g Grid) matchAt(p Pattern, x, y int) Match {
+	match := make(Match, 0, len(p))
+
+	for _, pc := range p {
+		cell := g.get(x+pc.offset.x, y+pc.offset.y)
+		if cell == nil {
+			return nil
+		}
+
+		if pc.char != Wildcard && cell.char != pc.char {
+			return nil
+		}
+
+		match = append(match, *cell)
+	}
+
+	return match
+}
+
+// find returns every place any of the patterns matches, in reading order of
+// the anchor cell.
+func (g Grid) find(patterns []Pattern) []Match {
+	matches := make([]Match, 0)
+
+	for y, row := range g.cells {
+		for x := range row {
+			for _, pattern := range patterns {
+				if match := g.matchAt(pattern, x, y); match != nil {
+					matches = append(matches, match)
+				}
+			}
+		}
+	}
+
+	return matches
+}
+
+func findWord(g Grid, word string) ([]Match, error) {
+	patterns, err := wordPatterns(word)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.find(patterns), nil
+}
+
+func findMask(g Grid, mask string) ([]Match, error) {
+	patterns, err := maskPatterns(mask)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.find(patterns), nil
+}
+
+func (m Match) String() string {
+	coords := make([]string, len(m))
+
+	for idx, cell := range m {
+		coords[idx] = fmt.Sprintf("(%d,%d)", cell.x, cell.y)
+	}
+
+	return strings.Join(coords, " ")
+}
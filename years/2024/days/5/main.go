@@ -7,6 +7,8 @@ import (
 	"os"
 	"strconv"
 	"strings"
+
+	"aoc/inputs"
 )
 
 func logErr(err error) {
@@ -33,7 +35,7 @@ func getInput(args []string) (string, error) {
 		fileName = "sample.txt"
 	}
 
-	data, err := os.ReadFile(fileName)
+	data, err := inputs.ReadFile(fileName)
 	if err != nil {
 		return "", err
 	}
@@ -2,10 +2,13 @@ package main
 
 import (
 	"errors"
+	"flag"
 	"fmt"
-	"math"
 	"os"
 	"strings"
+
+	"aoc/cmdline"
+	"aoc/inputs"
 )
 
 func logErr(err error) {
@@ -32,7 +35,7 @@ func getInput(args []string) (string, error) {
 		fileName = "sample.txt"
 	}
 
-	data, err := os.ReadFile(fileName)
+	data, err := inputs.ReadFile(fileName)
 	if err != nil {
 		return "", err
 	}
@@ -40,13 +43,80 @@ func getInput(args []string) (string, error) {
 	return strings.Trim(string(data), "\n"), nil
 }
 
+var (
+	animate   = flag.Bool("animate", false, "replay the guard's route in the terminal")
+	gifPath   = flag.String("gif", "", "export the guard's route as an animated GIF to this path")
+	fps       = flag.Int("fps", 30, "frame rate used by --animate and --gif")
+	scale     = flag.Int("scale", 4, "pixels per cell used by --gif")
+	loopIndex = flag.Int("loop", 0, "visualise the route with the nth (1-based) part 2 obstruction added")
+)
+
+func visualise(input string) error {
+	extra := -1
+
+	if *loopIndex > 0 {
+		obstructions, err := findLoopObstructions(input)
+		if err != nil {
+			return err
+		}
+
+		if *loopIndex > len(obstructions) {
+			return fmt.Errorf("only %d obstructions cause a loop", len(obstructions))
+		}
+
+		extra = obstructions[*loopIndex-1]
+	}
+
+	recorder, err := recordRoute(input, extra)
+	if err != nil {
+		return err
+	}
+
+	if *gifPath != "" {
+		file, err := os.Create(*gifPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		if err := recorder.writeGIF(file, *fps, *scale); err != nil {
+			return err
+		}
+	}
+
+	if *animate {
+		return recorder.play(os.Stdout, *fps)
+	}
+
+	return nil
+}
+
 func main() {
-	input, err := getInput(os.Args[1:])
+	args, err := cmdline.Parse(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		logErr(err)
+	}
+
+	// --loop only picks which route is visualised, it is a mistake on its own
+	if *loopIndex != 0 && !*animate && *gifPath == "" {
+		fmt.Fprintln(flag.CommandLine.Output(), "--loop needs --animate or --gif")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	input, err := getInput(args)
 
 	if err != nil {
 		logErr(err)
 	}
 
+	if *animate || *gifPath != "" {
+		if err := visualise(input); err != nil {
+			logErr(err)
+		}
+		return
+	}
+
 	p1, err := part1(input)
 
 	if err != nil {
@@ -84,8 +154,7 @@ func nextDirection(d Direction) Direction {
 }
 
 type Guard struct {
-	x, y, steps  int
-	facing       Direction
+	*Walker
 	obstructions []*Cell
 }
 
@@ -98,18 +167,13 @@ type Grid struct {
 	cells      [][]*Cell
 	lineLength int
 	guard      Guard
+	recorder   *Recorder
 }
 
-func newGrid(input string) Grid {
+func newGrid(input string) *Grid {
 	lineLength := strings.Index(input, "\n")
 
-	guard := Guard{
-		x:            0,
-		y:            0,
-		steps:        0,
-		facing:       up,
-		obstructions: make([]*Cell, 0),
-	}
+	guardX, guardY := 0, 0
 	cells := make([][]*Cell, 0)
 
 	for y, line := range strings.Split(input, "\n") {
@@ -117,8 +181,8 @@ func newGrid(input string) Grid {
 
 		for x, tile := range strings.Split(line, "") {
 			if tile == "^" {
-				guard.x = x
-				guard.y = y
+				guardX = x
+				guardY = y
 			}
 
 			row = append(row, &Cell{
@@ -134,65 +198,45 @@ func newGrid(input string) Grid {
 		cells = append(cells, row)
 	}
 
-	return Grid{
-		cells,
-		lineLength,
-		guard,
-	}
-}
-
-func (g *Grid) visit(x, y int) {
-	cell := g.cells[y][x]
-
-	if g.guard.facing == up || g.guard.facing == down {
-		cell.crossedY = true
-	} else {
-		cell.crossedX = true
+	grid := &Grid{
+		cells:      cells,
+		lineLength: lineLength,
+		guard: Guard{
+			obstructions: make([]*Cell, 0),
+		},
 	}
 
-	cell.visited = true
-}
+	grid.guard.Walker = newWalker(guardX, guardY, lineLength, len(cells), up, nextDirection, func(x, y int) bool {
+		return cells[y][x].obstructed
+	})
 
-func (g *Grid) getNextCell() *Cell {
-	x := g.guard.x + g.guard.facing.x
-	y := g.guard.y + g.guard.facing.y
+	grid.guard.onVisit = func(x, y int, facing Direction) {
+		visit(cells[y][x], facing)
 
-	if x < 0 || x >= g.lineLength || y < 0 || y >= g.lineLength {
-		return nil
+		if grid.recorder != nil {
+			grid.recorder.record(x, y, facing)
+		}
 	}
 
-	return g.cells[y][x]
-}
-
-func (g *Grid) moveGuard() (bool, error) {
-	g.visit(g.guard.x, g.guard.y)
-
-	nextCell := g.getNextCell()
-	// Just exit, nextCell is nil so we are out of the grid
-	if nextCell == nil {
-		return false, nil
+	grid.guard.onBlocked = func(x, y int) {
+		grid.guard.obstructions = append(grid.guard.obstructions, cells[y][x])
 	}
 
-	for nextCell.obstructed {
-		g.guard.facing = nextDirection(g.guard.facing)
-		g.visit(g.guard.x, g.guard.y)
-		g.guard.obstructions = append(g.guard.obstructions, nextCell)
+	return grid
+}
 
-		nextCell = g.getNextCell()
-		if nextCell == nil {
-			return false, nil
-		}
+func visit(cell *Cell, facing Direction) {
+	if facing == up || facing == down {
+		cell.crossedY = true
+	} else {
+		cell.crossedX = true
 	}
 
-	g.guard.x = nextCell.x
-	g.guard.y = nextCell.y
-	g.guard.steps++
-
-	if g.guard.steps > int(math.Pow(float64(g.lineLength), 2)) {
-		return false, errors.New("stuck in a loop")
-	}
+	cell.visited = true
+}
 
-	return true, nil
+func (g *Grid) moveGuard() (bool, error) {
+	return g.guard.step()
 }
 
 func (g *Grid) draw() {
@@ -244,6 +288,17 @@ func part1(input string) (int, error) {
 
 // 1732 -> too high
 func part2(input string) (int, error) {
+	obstructions, err := findLoopObstructions(input)
+	if err != nil {
+		return -1, err
+	}
+
+	return len(obstructions), nil
+}
+
+// part2Naive obstructs every cell in turn and simulates from scratch, it is
+// kept as the baseline for the benchmarks.
+func part2Naive(input string) (int, error) {
 	result := 0
 
 	lineLength := strings.Index(input, "\n")
@@ -266,8 +321,11 @@ func part2(input string) (int, error) {
 					exit, err = grid.moveGuard()
 				}
 
-				if err != nil {
+				var loop ErrLoop
+				if errors.As(err, &loop) {
 					result++
+				} else if err != nil {
+					return -1, err
 				}
 			}
 
@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+const sample = `....#.....
+.........#
+..........
+..#.......
+.......#..
+..........
+.#..^.....
+........#.
+#.........
+......#...`
+
+func benchmarkPart2(b *testing.B, solve func(string) (int, error)) {
+	for i := 0; i < b.N; i++ {
+		result, err := solve(sample)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		if result != 6 {
+			b.Fatalf("got %d, expected 6", result)
+		}
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	benchmarkPart2(b, part2)
+}
+
+func BenchmarkPart2Naive(b *testing.B) {
+	benchmarkPart2(b, part2Naive)
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// Directions in the order the guard turns through them
+var turnOrder = [4]Direction{up, right, down, left}
+
+func directionIndex(d Direction) int {
+	for idx, dir := range turnOrder {
+		if dir == d {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// jumpTable stores, for every cell and facing, the index of the cell the guard
+// stops on before the next obstruction, or -1 if it would walk off the grid.
+type jumpTable struct {
+	width, height int
+	obstructed    []bool
+	next          [4][]int
+}
+
+// Cells are filled in so the one ahead is always known first, walking against
+// the direction of travel.
+func scanOrder(size, step int) (int, int, int) {
+	if step > 0 {
+		return size - 1, -1, -1
+	}
+
+	return 0, size, 1
+}
+
+func newJumpTable(g *Grid) *jumpTable {
+	width, height := g.guard.width, g.guard.height
+
+	table := &jumpTable{
+		width:      width,
+		height:     height,
+		obstructed: make([]bool, width*height),
+	}
+
+	for _, row := range g.cells {
+		for _, cell := range row {
+			table.obstructed[cell.y*width+cell.x] = cell.obstructed
+		}
+	}
+
+	for d, dir := range turnOrder {
+		next := make([]int, width*height)
+
+		yStart, yEnd, yStep := scanOrder(height, dir.y)
+		xStart, xEnd, xStep := scanOrder(width, dir.x)
+
+		for y := yStart; y != yEnd; y += yStep {
+			for x := xStart; x != xEnd; x += xStep {
+				idx := y*width + x
+				aheadX, aheadY := x+dir.x, y+dir.y
+
+				if aheadX < 0 || aheadX >= width || aheadY < 0 || aheadY >= height {
+					next[idx] = -1
+				} else if ahead := aheadY*width + aheadX; table.obstructed[ahead] {
+					next[idx] = idx
+				} else {
+					next[idx] = next[ahead]
+				}
+			}
+		}
+
+		table.next[d] = next
+	}
+
+	return table
+}
+
+// jump returns where the guard stops when walking from idx in direction d,
+// taking the extra obstruction into account. Returns -1 if it leaves the grid.
+func (t *jumpTable) jump(idx, d, extra int) int {
+	target := t.next[d][idx]
+	dir := turnOrder[d]
+
+	x, y := idx%t.width, idx/t.width
+	ex, ey := extra%t.width, extra/t.width
+
+	// Walking off the grid is the same as stopping just beyond the edge
+	tx, ty := x, y
+	if target == -1 {
+		if dir.x != 0 {
+			tx = (t.width-1)*max(dir.x, 0) + dir.x
+		} else {
+			ty = (t.height-1)*max(dir.y, 0) + dir.y
+		}
+	} else {
+		tx, ty = target%t.width, target/t.width
+	}
+
+	// Only matters if the extra obstruction sits between us and the target
+	if dir.x == 0 && ex == x && (ey-y)*dir.y > 0 && (ty-ey)*dir.y >= 0 {
+		return (ey-dir.y)*t.width + ex
+	}
+
+	if dir.y == 0 && ey == y && (ex-x)*dir.x > 0 && (tx-ex)*dir.x >= 0 {
+		return ey*t.width + ex - dir.x
+	}
+
+	return target
+}
+
+// loops reports whether the guard, starting at idx facing d, gets stuck with
+// an extra obstruction placed on the grid. Turning points are stamped into
+// seen so the buffer can be reused between candidates without clearing.
+func (t *jumpTable) loops(idx, d, extra int, seen []int32, stamp int32) bool {
+	for {
+		idx = t.jump(idx, d, extra)
+		if idx == -1 {
+			return false
+		}
+
+		state := idx*4 + d
+		if seen[state] == stamp {
+			return true
+		}
+
+		seen[state] = stamp
+		d = (d + 1) % 4
+	}
+}
+
+type candidate struct {
+	// The cell to obstruct, and the state of the guard just before entering it
+	obstruction, start, facing int
+}
+
+// pathCandidates walks the unmodified route and returns each cell on it once,
+// alongside where the guard was when it first stepped into that cell.
+func pathCandidates(g *Grid) ([]candidate, error) {
+	width := g.guard.width
+	startIdx := g.guard.y*width + g.guard.x
+
+	firstSeen := make(map[int]bool)
+	candidates := make([]candidate, 0)
+
+	moved, err := g.moveGuard()
+	for moved && err == nil {
+		idx := g.guard.y*width + g.guard.x
+
+		if idx != startIdx && !firstSeen[idx] {
+			firstSeen[idx] = true
+
+			prevX := g.guard.x - g.guard.facing.x
+			prevY := g.guard.y - g.guard.facing.y
+
+			candidates = append(candidates, candidate{
+				obstruction: idx,
+				start:       prevY*width + prevX,
+				facing:      directionIndex(g.guard.facing),
+			})
+		}
+
+		moved, err = g.moveGuard()
+	}
+
+	return candidates, err
+}
+
+// findLoopObstructions returns the index of every cell that traps the guard in
+// a loop when obstructed, in reading order.
+func findLoopObstructions(input string) ([]int, error) {
+	grid := newGrid(input)
+	table := newJumpTable(grid)
+
+	candidates, err := pathCandidates(grid)
+	if err != nil {
+		return nil, err
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	jobs := make(chan candidate)
+	results := make([][]int, workers)
+
+	var wg sync.WaitGroup
+
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			seen := make([]int32, table.width*table.height*4)
+			stamp := int32(0)
+
+			for c := range jobs {
+				stamp++
+
+				if table.loops(c.start, c.facing, c.obstruction, seen, stamp) {
+					results[worker] = append(results[worker], c.obstruction)
+				}
+			}
+		}()
+	}
+
+	for _, c := range candidates {
+		jobs <- c
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	obstructions := slices.Concat(results...)
+	slices.Sort(obstructions)
+
+	return obstructions, nil
+}
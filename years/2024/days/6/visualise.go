@@ -0,0 +1,278 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"time"
+)
+
+// Frame is a single visit made by the guard, either arriving in a cell or
+// turning on the spot.
+type Frame struct {
+	x, y   int
+	facing Direction
+}
+
+// Recorder keeps every frame of a guard's route, so it can be replayed
+type Recorder struct {
+	width, height int
+	obstructed    []bool
+	// Index of the obstruction added for part 2, or -1 if there is none
+	extra  int
+	frames []Frame
+	looped bool
+}
+
+func (r *Recorder) record(x, y int, facing Direction) {
+	r.frames = append(r.frames, Frame{x, y, facing})
+}
+
+// recordRoute walks the guard until it leaves the grid or gets stuck,
+// recording each step. If extra is not -1 that cell is obstructed first.
+func recordRoute(input string, extra int) (*Recorder, error) {
+	grid := newGrid(input)
+	width, height := grid.guard.width, grid.guard.height
+
+	recorder := &Recorder{
+		width:      width,
+		height:     height,
+		obstructed: make([]bool, width*height),
+		extra:      extra,
+		frames:     make([]Frame, 0),
+	}
+
+	if extra != -1 {
+		grid.cells[extra/width][extra%width].obstructed = true
+	}
+
+	for _, row := range grid.cells {
+		for _, cell := range row {
+			recorder.obstructed[cell.y*width+cell.x] = cell.obstructed
+		}
+	}
+
+	grid.recorder = recorder
+
+	exit, err := grid.moveGuard()
+	for exit && err == nil {
+		exit, err = grid.moveGuard()
+	}
+
+	var loop ErrLoop
+	if errors.As(err, &loop) {
+		recorder.looped = true
+	} else if err != nil {
+		return nil, err
+	}
+
+	return recorder, nil
+}
+
+func guardGlyph(d Direction) byte {
+	switch d {
+	case up:
+		return '^'
+	case right:
+		return '>'
+	case down:
+		return 'v'
+	default:
+		return '<'
+	}
+}
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[1;31m"
+	ansiYellow = "\x1b[1;33m"
+	ansiBlue   = "\x1b[34m"
+)
+
+// trail tracks which ways each cell has been crossed during a replay
+type trail struct {
+	crossedX, crossedY []bool
+}
+
+func newTrail(size int) trail {
+	return trail{make([]bool, size), make([]bool, size)}
+}
+
+func (t trail) cross(idx int, facing Direction) {
+	if facing == up || facing == down {
+		t.crossedY[idx] = true
+	} else {
+		t.crossedX[idx] = true
+	}
+}
+
+func (r *Recorder) tile(t trail, idx int) string {
+	if idx == r.extra {
+		return ansiRed + "O" + ansiReset
+	}
+
+	if r.obstructed[idx] {
+		return "#"
+	}
+
+	if t.crossedX[idx] && t.crossedY[idx] {
+		return ansiBlue + "+" + ansiReset
+	} else if t.crossedX[idx] {
+		return ansiBlue + "-" + ansiReset
+	} else if t.crossedY[idx] {
+		return ansiBlue + "|" + ansiReset
+	}
+
+	return "."
+}
+
+// play replays the route as an ANSI terminal animation. The whole grid is
+// drawn once and only the cells that change are redrawn after that.
+func (r *Recorder) play(out io.Writer, fps int) error {
+	if fps <= 0 {
+		return fmt.Errorf("invalid frame rate: %d", fps)
+	}
+
+	w := bufio.NewWriter(out)
+	t := newTrail(r.width * r.height)
+
+	moveTo := func(x, y int) {
+		fmt.Fprintf(w, "\x1b[%d;%dH", y+1, x+1)
+	}
+
+	fmt.Fprint(w, "\x1b[2J\x1b[?25l")
+	for y := 0; y < r.height; y++ {
+		moveTo(0, y)
+		for x := 0; x < r.width; x++ {
+			fmt.Fprint(w, r.tile(t, y*r.width+x))
+		}
+	}
+
+	ticker := time.NewTicker(time.Second / time.Duration(fps))
+	defer ticker.Stop()
+
+	prev := -1
+
+	for _, frame := range r.frames {
+		idx := frame.y*r.width + frame.x
+		t.cross(idx, frame.facing)
+
+		if prev != -1 && prev != idx {
+			moveTo(prev%r.width, prev/r.width)
+			fmt.Fprint(w, r.tile(t, prev))
+		}
+
+		moveTo(frame.x, frame.y)
+		fmt.Fprintf(w, "%s%c%s", ansiYellow, guardGlyph(frame.facing), ansiReset)
+		prev = idx
+
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		<-ticker.C
+	}
+
+	moveTo(0, r.height)
+
+	outcome := fmt.Sprintf("Left the grid after %d frames", len(r.frames))
+	if r.looped {
+		outcome = fmt.Sprintf("Stuck in a loop after %d frames", len(r.frames))
+	}
+
+	fmt.Fprintf(w, "\x1b[?25h%s\n", outcome)
+
+	return w.Flush()
+}
+
+var gifPalette = color.Palette{
+	color.RGBA{0x0f, 0x0f, 0x23, 0xff}, // Empty
+	color.RGBA{0x99, 0x99, 0x99, 0xff}, // Obstruction
+	color.RGBA{0x33, 0x66, 0xcc, 0xff}, // Trail
+	color.RGBA{0xff, 0xff, 0x66, 0xff}, // Guard
+	color.RGBA{0xff, 0x33, 0x33, 0xff}, // Added obstruction
+}
+
+const (
+	gifEmpty uint8 = iota
+	gifObstruction
+	gifTrail
+	gifGuard
+	gifExtra
+)
+
+func fillCell(img *image.Paletted, x, y, scale int, c uint8) {
+	for py := y * scale; py < (y+1)*scale; py++ {
+		for px := x * scale; px < (x+1)*scale; px++ {
+			img.SetColorIndex(px, py, c)
+		}
+	}
+}
+
+// writeGIF exports the route as an animated GIF, with each cell drawn as a
+// scale×scale square. Only the first frame covers the full grid, later frames
+// just redraw the cells the guard moved between.
+func (r *Recorder) writeGIF(out io.Writer, fps, scale int) error {
+	if fps <= 0 {
+		return fmt.Errorf("invalid frame rate: %d", fps)
+	}
+
+	if scale <= 0 {
+		return fmt.Errorf("invalid scale: %d", scale)
+	}
+
+	// GIF delays are in hundredths of a second
+	delay := max(100/fps, 1)
+
+	bounds := image.Rect(0, 0, r.width*scale, r.height*scale)
+	first := image.NewPaletted(bounds, gifPalette)
+
+	for idx, obstructed := range r.obstructed {
+		if idx == r.extra {
+			fillCell(first, idx%r.width, idx/r.width, scale, gifExtra)
+		} else if obstructed {
+			fillCell(first, idx%r.width, idx/r.width, scale, gifObstruction)
+		}
+	}
+
+	anim := &gif.GIF{
+		Image:    []*image.Paletted{first},
+		Delay:    []int{delay},
+		Disposal: []byte{gif.DisposalNone},
+		Config: image.Config{
+			ColorModel: gifPalette,
+			Width:      bounds.Dx(),
+			Height:     bounds.Dy(),
+		},
+	}
+
+	prev := -1
+
+	for _, frame := range r.frames {
+		rect := image.Rect(frame.x*scale, frame.y*scale, (frame.x+1)*scale, (frame.y+1)*scale)
+		if prev != -1 {
+			px, py := prev%r.width, prev/r.width
+			rect = rect.Union(image.Rect(px*scale, py*scale, (px+1)*scale, (py+1)*scale))
+		}
+
+		img := image.NewPaletted(rect, gifPalette)
+		for y := rect.Min.Y / scale; y < rect.Max.Y/scale; y++ {
+			for x := rect.Min.X / scale; x < rect.Max.X/scale; x++ {
+				fillCell(img, x, y, scale, gifTrail)
+			}
+		}
+
+		fillCell(img, frame.x, frame.y, scale, gifGuard)
+		prev = frame.y*r.width + frame.x
+
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, delay)
+		anim.Disposal = append(anim.Disposal, gif.DisposalNone)
+	}
+
+	return gif.EncodeAll(out, anim)
+}
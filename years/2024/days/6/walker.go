@@ -0,0 +1,125 @@
+package main
+
+import "fmt"
+
+// ErrLoop is returned by a Walker that arrives at a position it has already
+// left while facing the same way, meaning it will never leave the grid.
+type ErrLoop struct {
+	x, y   int
+	facing Direction
+}
+
+func (e ErrLoop) Error() string {
+	return fmt.Sprintf("stuck in a loop at (%d, %d) facing (%d, %d)", e.x, e.y, e.facing.x, e.facing.y)
+}
+
+// Each of the 8 unit directions gets its own bit, so the seen state of a
+// single cell fits in one byte.
+func directionBit(d Direction) uint8 {
+	idx := (d.y+1)*3 + d.x + 1
+
+	// Skip the centre, {0, 0} is not a direction
+	if idx > 4 {
+		idx--
+	}
+
+	return 1 << idx
+}
+
+type Walker struct {
+	x, y, steps   int
+	width, height int
+	facing        Direction
+
+	// turn picks the new facing when the next cell is blocked.
+	turn func(Direction) Direction
+	// blocked reports whether the cell at x, y cannot be entered.
+	blocked func(x, y int) bool
+	// onVisit, if set, is called for the current cell before every move and
+	// again after every turn.
+	onVisit func(x, y int, facing Direction)
+	// onBlocked, if set, is called with each cell that causes a turn.
+	onBlocked func(x, y int)
+
+	seen []uint8
+}
+
+func newWalker(x, y, width, height int, facing Direction, turn func(Direction) Direction, blocked func(x, y int) bool) *Walker {
+	return &Walker{
+		x:       x,
+		y:       y,
+		width:   width,
+		height:  height,
+		facing:  facing,
+		turn:    turn,
+		blocked: blocked,
+		seen:    make([]uint8, width*height),
+	}
+}
+
+func (w *Walker) inBounds(x, y int) bool {
+	return x >= 0 && x < w.width && y >= 0 && y < w.height
+}
+
+func (w *Walker) visit() {
+	if w.onVisit != nil {
+		w.onVisit(w.x, w.y, w.facing)
+	}
+}
+
+// step moves the walker forward one cell, turning as many times as needed
+// first. It returns false once the walker has left the grid, and an ErrLoop
+// if it is about to repeat a move it has already made.
+func (w *Walker) step() (bool, error) {
+	w.visit()
+
+	for turns := 0; ; turns++ {
+		x := w.x + w.facing.x
+		y := w.y + w.facing.y
+
+		if !w.inBounds(x, y) {
+			return false, nil
+		}
+
+		if !w.blocked(x, y) {
+			break
+		}
+
+		// Boxed in on every side, so turning forever is a loop as well
+		if turns == 8 {
+			return false, ErrLoop{w.x, w.y, w.facing}
+		}
+
+		if w.onBlocked != nil {
+			w.onBlocked(x, y)
+		}
+
+		w.facing = w.turn(w.facing)
+		w.visit()
+	}
+
+	idx := w.y*w.width + w.x
+	bit := directionBit(w.facing)
+
+	if w.seen[idx]&bit != 0 {
+		return false, ErrLoop{w.x, w.y, w.facing}
+	}
+
+	w.seen[idx] |= bit
+
+	w.x += w.facing.x
+	w.y += w.facing.y
+	w.steps++
+
+	return true, nil
+}
+
+// walk steps until the walker leaves the grid or loops.
+func (w *Walker) walk() error {
+	moved, err := w.step()
+	for moved && err == nil {
+		moved, err = w.step()
+	}
+
+	return err
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// harmonicRange is the multiples from min to max inclusive, max is
+// math.MaxInt when the range is open ended.
+type harmonicRange struct {
+	min, max int
+}
+
+// Harmonics is the set of multiples of the step between two antennas, counted
+// from the antenna furthest back, at which antinodes appear. With antennas A
+// and B, and steps left unreduced, k=1 lands on B, k=2 is one step beyond B,
+// and so on. Every pair is used both ways round, so the same multiples appear
+// beyond A as well.
+type Harmonics []harmonicRange
+
+var (
+	// partOneHarmonics puts an antinode just beyond each antenna
+	partOneHarmonics = Harmonics{{2, 2}}
+	// partTwoHarmonics puts an antinode on every cell in line, antennas included
+	partTwoHarmonics = Harmonics{{1, math.MaxInt}}
+)
+
+// parseHarmonics accepts a comma separated list of multiples, each either a
+// single number "2", a range "1..5", or an open range "1..".
+func parseHarmonics(spec string) (Harmonics, error) {
+	result := make(Harmonics, 0)
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+
+		low, high, isRange := strings.Cut(part, "..")
+
+		min, err := strconv.Atoi(low)
+		if err != nil || min < 0 {
+			return nil, fmt.Errorf("invalid harmonics: %q", spec)
+		}
+
+		max := min
+		if isRange && high == "" {
+			max = math.MaxInt
+		} else if isRange {
+			max, err = strconv.Atoi(high)
+			if err != nil || max < min {
+				return nil, fmt.Errorf("invalid harmonics: %q", spec)
+			}
+		}
+
+		result = append(result, harmonicRange{min, max})
+	}
+
+	slices.SortFunc(result, func(a, b harmonicRange) int {
+		return a.min - b.min
+	})
+
+	return result, nil
+}
+
+func (h Harmonics) String() string {
+	parts := make([]string, len(h))
+
+	for idx, r := range h {
+		switch r.max {
+		case r.min:
+			parts[idx] = strconv.Itoa(r.min)
+		case math.MaxInt:
+			parts[idx] = fmt.Sprintf("%d..", r.min)
+		default:
+			parts[idx] = fmt.Sprintf("%d..%d", r.min, r.max)
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
+
+func gcd(a, b int) int {
+	a, b = max(a, -a), max(b, -b)
+
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
+
+// reduce shortens the step to the smallest one in the same direction that
+// still lands on whole cells, so every cell in line is reached.
+func (s Step) reduce() Step {
+	divisor := gcd(s.x, s.y)
+	if divisor == 0 {
+		return s
+	}
+
+	return Step{s.x / divisor, s.y / divisor}
+}
+
+// AntiNodeEngine works out where antinodes appear for any set of harmonics
+type AntiNodeEngine struct {
+	grid      *Grid
+	harmonics Harmonics
+	// Whether steps are reduced by their GCD before the harmonics are applied
+	reduce bool
+}
+
+func newAntiNodeEngine(grid *Grid, harmonics Harmonics, reduce bool) AntiNodeEngine {
+	return AntiNodeEngine{grid, harmonics, reduce}
+}
+
+// pairAntiNodes returns the antinodes on the far side of to, going from
+// from. Only the harmonics which land on the grid are walked, as the cells
+// on the grid along a line are never split by cells off it.
+func (e AntiNodeEngine) pairAntiNodes(from, to *Node) []*Node {
+	antiNodes := make([]*Node, 0)
+	step := to.getStep(from)
+
+	if e.reduce {
+		step = step.reduce()
+	}
+
+	for _, r := range e.harmonics {
+		for k := r.min; k <= r.max; k++ {
+			node := e.grid.getFromStep(from, Step{step.x * k, step.y * k})
+			if node == nil {
+				break
+			}
+
+			antiNodes = append(antiNodes, node)
+		}
+	}
+
+	return antiNodes
+}
+
+// frequencyAntiNodes returns the distinct antinodes produced by the antennas
+// of a single frequency.
+func (e AntiNodeEngine) frequencyAntiNodes(nodes []*Node) map[*Node]bool {
+	antiNodes := make(map[*Node]bool)
+
+	for i, from := range nodes {
+		for j, to := range nodes {
+			if i == j {
+				continue
+			}
+
+			for _, node := range e.pairAntiNodes(from, to) {
+				antiNodes[node] = true
+			}
+		}
+	}
+
+	return antiNodes
+}
+
+// FrequencyCount is the number of distinct antinodes one frequency produces
+type FrequencyCount struct {
+	frequency string
+	antennas  int
+	antiNodes int
+}
+
+// count marks every antinode on the grid and returns the total along with
+// the count for each frequency, ordered by frequency.
+func (e AntiNodeEngine) count() (int, []FrequencyCount) {
+	perFrequency := make([]FrequencyCount, 0, len(e.grid.freqMap))
+
+	for _, frequency := range e.grid.frequencies() {
+		nodes := e.grid.freqMap[frequency]
+		antiNodes := e.frequencyAntiNodes(nodes)
+
+		for node := range antiNodes {
+			node.antiNode = true
+		}
+
+		perFrequency = append(perFrequency, FrequencyCount{frequency, len(nodes), len(antiNodes)})
+	}
+
+	return e.grid.antiNodeCount(), perFrequency
+}
+
+// printFrequencies runs the engine with the given harmonics and prints the
+// antinode count for each frequency, then the total.
+func printFrequencies(input string, harmonics Harmonics, reduce bool) error {
+	grid, err := newGrid(input)
+	if err != nil {
+		return err
+	}
+
+	total, perFrequency := newAntiNodeEngine(&grid, harmonics, reduce).count()
+
+	fmt.Printf("Harmonics %s, reduced steps %t\n", harmonics, reduce)
+
+	for _, count := range perFrequency {
+		fmt.Printf("%s: %d antenna(s), %d antinode(s)\n", count.frequency, count.antennas, count.antiNodes)
+	}
+
+	fmt.Printf("Total: %d\n", total)
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+type FrequencyBreakdown struct {
+	Frequency string `json:"frequency"`
+	Antennas  int    `json:"antennas"`
+	AntiNodes int    `json:"antinodes"`
+	// Antinodes which another frequency produces as well
+	Shared int `json:"shared"`
+	// Antinodes on a cell holding an antenna, of any frequency
+	OnAntennas int `json:"on_antennas"`
+}
+
+type Breakdown struct {
+	Harmonics   string               `json:"harmonics"`
+	Reduced     bool                 `json:"reduced"`
+	Total       int                  `json:"total"`
+	Frequencies []FrequencyBreakdown `json:"frequencies"`
+}
+
+// breakdown is where the antinodes come from, frequency by frequency. Shared
+// antinodes are only counted once in the total, so when it is off by a few
+// the shared and on antenna columns are the first place to look.
+func (e AntiNodeEngine) breakdown() Breakdown {
+	frequencies := e.grid.frequencies()
+	antiNodes := make(map[string]map[*Node]bool, len(frequencies))
+	producers := make(map[*Node]int)
+
+	for _, frequency := range frequencies {
+		antiNodes[frequency] = e.frequencyAntiNodes(e.grid.freqMap[frequency])
+
+		for node := range antiNodes[frequency] {
+			producers[node]++
+		}
+	}
+
+	result := Breakdown{
+		Harmonics:   e.harmonics.String(),
+		Reduced:     e.reduce,
+		Total:       len(producers),
+		Frequencies: make([]FrequencyBreakdown, 0, len(frequencies)),
+	}
+
+	for _, frequency := range frequencies {
+		report := FrequencyBreakdown{
+			Frequency: frequency,
+			Antennas:  len(e.grid.freqMap[frequency]),
+			AntiNodes: len(antiNodes[frequency]),
+		}
+
+		for node := range antiNodes[frequency] {
+			if producers[node] > 1 {
+				report.Shared++
+			}
+
+			if node.frequency != "" {
+				report.OnAntennas++
+			}
+		}
+
+		result.Frequencies = append(result.Frequencies, report)
+	}
+
+	return result
+}
+
+func writeBreakdownTable(w io.Writer, breakdowns []Breakdown) error {
+	for idx, breakdown := range breakdowns {
+		if idx > 0 {
+			fmt.Fprintln(w)
+		}
+
+		fmt.Fprintf(w, "Harmonics %s, reduced steps %t: %d antinode(s)\n", breakdown.Harmonics, breakdown.Reduced, breakdown.Total)
+
+		table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(table, "frequency\tantennas\tantinodes\tshared\ton antennas\t")
+
+		for _, report := range breakdown.Frequencies {
+			fmt.Fprintf(table, "%s\t%d\t%d\t%d\t%d\t\n", report.Frequency, report.Antennas, report.AntiNodes, report.Shared, report.OnAntennas)
+		}
+
+		if err := table.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeBreakdown reports on the given harmonics, or on both parts when there
+// are none.
+func writeBreakdown(w io.Writer, input, format string, harmonics Harmonics, reduce bool) error {
+	sets := []Harmonics{harmonics}
+	if harmonics == nil {
+		sets = []Harmonics{partOneHarmonics, partTwoHarmonics}
+	}
+
+	breakdowns := make([]Breakdown, 0, len(sets))
+
+	for _, set := range sets {
+		grid, err := newGrid(input)
+		if err != nil {
+			return err
+		}
+
+		breakdowns = append(breakdowns, newAntiNodeEngine(&grid, set, reduce).breakdown())
+	}
+
+	switch format {
+	case "table":
+		return writeBreakdownTable(w, breakdowns)
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(breakdowns)
+	default:
+		return fmt.Errorf("unsupported breakdown format: %q", format)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"aoc/render"
+)
+
+var (
+	otherAntenna = color.RGBA{0x55, 0x55, 0x66, 0xff}
+	bothColour   = color.RGBA{0xff, 0xff, 0xff, 0xff}
+)
+
+// frequencyName describes a frequency for a file name. Frequencies differing
+// only by case, like a and A, would otherwise overwrite each other's files on
+// case insensitive file systems.
+func frequencyName(frequency string) string {
+	switch {
+	case strings.ToLower(frequency) != frequency:
+		return "upper-" + frequency
+	case strings.ToUpper(frequency) != frequency:
+		return "lower-" + frequency
+	default:
+		return frequency
+	}
+}
+
+// renderFrequencies writes one image per frequency and part to dir, showing
+// that frequency's antennas, the antinodes they produce, and any antenna
+// which is also one of its antinodes.
+func renderFrequencies(input, dir, format string) error {
+	if format != "png" && format != "svg" {
+		return fmt.Errorf("unsupported image format: %q", format)
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	grid, err := newGrid(input)
+	if err != nil {
+		return err
+	}
+
+	frequencies := grid.frequencies()
+
+	for idx, frequency := range frequencies {
+		colour := render.Distinct(idx, len(frequencies))
+		r, g, b, _ := colour.RGBA()
+		faded := color.RGBA{uint8(r >> 9), uint8(g >> 9), uint8(b >> 9), 0xff}
+
+		for part, harmonics := range []Harmonics{partOneHarmonics, partTwoHarmonics} {
+			engine := newAntiNodeEngine(&grid, harmonics, false)
+			antiNodes := engine.frequencyAntiNodes(grid.freqMap[frequency])
+
+			image := render.Grid[*Node]{
+				Cells: grid.grid,
+				Colour: func(n *Node) color.Color {
+					if n.frequency == frequency && antiNodes[n] {
+						return bothColour
+					} else if n.frequency == frequency {
+						return colour
+					} else if antiNodes[n] {
+						return faded
+					} else if n.frequency != "" {
+						return otherAntenna
+					}
+
+					return nil
+				},
+				Label: func(n *Node) string {
+					return n.frequency
+				},
+			}
+
+			name := fmt.Sprintf("part%d-%s.%s", part+1, frequencyName(frequency), format)
+			if err := image.Save(filepath.Join(dir, name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"aoc/cmdline"
+	"aoc/inputs"
+)
+
+func logErr(err error) {
+	fmt.Printf("ERROR: %+v\n", err)
+
+	os.Exit(1)
+}
+
+func useSample(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	if args[0] == "sample" {
+		return true
+	}
+
+	return false
+}
+
+func getInput(args []string) (string, error) {
+	fileName := "input.txt"
+	if useSample(args) {
+		fileName = "sample.txt"
+	}
+
+	data, err := inputs.ReadFile(fileName)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Trim(string(data), "\n"), nil
+}
+
+var (
+	renderDir    = flag.String("render", "", "write an image of each frequency's antinodes to this directory")
+	renderFormat = flag.String("format", "png", "image format used by --render, png or svg")
+	harmonics    = flag.String("harmonics", "", "count antinodes at these multiples of each step instead of running the parts, e.g. 2, 1.. or 1..5")
+	reduceSteps  = flag.Bool("reduce", false, "with --harmonics, reduce each step by its GCD so every cell in line is reached")
+	breakdown    = flag.String("breakdown", "", "report where each frequency's antinodes land as a table or json, for --harmonics or both parts")
+)
+
+func main() {
+	args, err := cmdline.Parse(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		logErr(err)
+	}
+
+	input, err := getInput(args)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	var set Harmonics
+	if *harmonics != "" {
+		set, err = parseHarmonics(*harmonics)
+		if err != nil {
+			logErr(err)
+		}
+	}
+
+	if *breakdown != "" {
+		if err := writeBreakdown(os.Stdout, input, *breakdown, set, *reduceSteps); err != nil {
+			logErr(err)
+		}
+
+		return
+	}
+
+	if set != nil {
+		if err := printFrequencies(input, set, *reduceSteps); err != nil {
+			logErr(err)
+		}
+
+		return
+	}
+
+	if *renderDir != "" {
+		if err := renderFrequencies(input, *renderDir, *renderFormat); err != nil {
+			logErr(err)
+		}
+	}
+
+	p1, err := part1(input)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	fmt.Printf("Part 1: %d\n", p1)
+
+	p2, err := part2(input)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	fmt.Printf("Part 2: %d\n", p2)
+}
+
+type Step struct{ x, y int }
+
+func (s *Step) invert() {
+	s.x = -s.x
+	s.y = -s.y
+}
+
+type Node struct {
+	x, y      int
+	frequency string
+	antiNode  bool
+}
+
+func (n *Node) getStep(o *Node) Step {
+	return Step{n.x - o.x, n.y - o.y}
+}
+
+type Grid struct {
+	width, height int
+	grid          [][]*Node
+	freqMap       map[string][]*Node
+}
+
+func newGrid(input string) (Grid, error) {
+	grid := make([][]*Node, 0)
+	freqMap := make(map[string][]*Node)
+
+	lines := strings.Split(input, "\n")
+	width := len(lines[0])
+
+	for y, line := range lines {
+		if len(line) != width {
+			return Grid{}, fmt.Errorf("row %d is %d wide, expected %d", y, len(line), width)
+		}
+
+		row := make([]*Node, 0)
+
+		for x, column := range strings.Split(line, "") {
+			frequency := ""
+			if column != "." {
+				frequency = column
+			}
+
+			node := &Node{
+				x, y, frequency, false,
+			}
+
+			row = append(row, node)
+
+			if frequency == "" {
+				continue
+			}
+
+			freqNodes, found := freqMap[frequency]
+			if !found {
+				freqNodes = make([]*Node, 0)
+			}
+
+			freqNodes = append(freqNodes, node)
+			freqMap[frequency] = freqNodes
+		}
+
+		grid = append(grid, row)
+	}
+
+	return Grid{
+		width,
+		len(grid),
+		grid,
+		freqMap,
+	}, nil
+}
+
+// frequencies lists every frequency on the grid in order
+func (g *Grid) frequencies() []string {
+	frequencies := make([]string, 0, len(g.freqMap))
+	for frequency := range g.freqMap {
+		frequencies = append(frequencies, frequency)
+	}
+
+	slices.Sort(frequencies)
+
+	return frequencies
+}
+
+func (g *Grid) antiNodeCount() int {
+	result := 0
+
+	for _, row := range g.grid {
+		for _, node := range row {
+			if node.antiNode {
+				result++
+			}
+		}
+	}
+
+	return result
+}
+
+func (g *Grid) getFromStep(n *Node, s Step) *Node {
+	x := n.x + s.x
+	y := n.y + s.y
+
+	if x < 0 || x >= g.width || y < 0 || y >= g.height {
+		return nil
+	}
+
+	return g.grid[y][x]
+}
+
+func (g *Grid) draw() {
+	for _, row := range g.grid {
+		for _, node := range row {
+			if node.antiNode {
+				print("#")
+			} else if node.frequency != "" {
+				print(node.frequency)
+			} else {
+				print(".")
+			}
+		}
+
+		println()
+	}
+}
+
+func part1(input string) (int, error) {
+	grid, err := newGrid(input)
+	if err != nil {
+		return -1, err
+	}
+
+	total, _ := newAntiNodeEngine(&grid, partOneHarmonics, false).count()
+
+	return total, nil
+}
+
+func part2(input string) (int, error) {
+	grid, err := newGrid(input)
+	if err != nil {
+		return -1, err
+	}
+
+	total, _ := newAntiNodeEngine(&grid, partTwoHarmonics, false).count()
+
+	return total, nil
+}
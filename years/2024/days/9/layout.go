@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Bright 256 colour codes, changing every 36 IDs so that IDs which share a
+// glyph can still be told apart.
+var idColours = []int{15, 11, 14, 10, 13, 9, 12, 208, 118, 201, 45, 226}
+
+// layoutRenderer draws the disk the way the puzzle does, "." for free space
+// and the file ID otherwise. IDs are written in base 36 so they stay one
+// character wide, which means they wrap around from 36 onwards unless colour
+// is on.
+type layoutRenderer struct {
+	colour bool
+}
+
+func (r layoutRenderer) glyph(sb *strings.Builder, id int) {
+	if id < 0 {
+		sb.WriteByte('.')
+		return
+	}
+
+	glyph := strconv.FormatInt(int64(id%36), 36)
+
+	if r.colour {
+		fmt.Fprintf(sb, "\x1b[38;5;%dm%s\x1b[0m", idColours[(id/36)%len(idColours)], glyph)
+	} else {
+		sb.WriteString(glyph)
+	}
+}
+
+func (r layoutRenderer) memory(memory []int) string {
+	var sb strings.Builder
+
+	for _, id := range memory {
+		r.glyph(&sb, id)
+	}
+
+	return sb.String()
+}
+
+func (r layoutRenderer) files(files []File) string {
+	var sb strings.Builder
+
+	for _, file := range files {
+		for _, id := range file.toIntArray() {
+			r.glyph(&sb, id)
+		}
+	}
+
+	return sb.String()
+}
+
+// moveLog prints each move made while compacting the disk, when enabled
+type moveLog struct {
+	layoutRenderer
+	enabled bool
+}
+
+var moves moveLog
+
+func (l moveLog) start(name, layout string) {
+	if l.enabled {
+		fmt.Printf("%s\n%s\n", name, layout)
+	}
+}
+
+// The layout is only rendered when logging, as it is expensive on the full input
+func (l moveLog) move(id, size, from, to int, layout func() string) {
+	if l.enabled {
+		fmt.Printf("move %d block(s) of file %d from %d to %d\n%s\n", size, id, from, to, layout())
+	}
+}
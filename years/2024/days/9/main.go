@@ -0,0 +1,325 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"aoc/cmdline"
+	"aoc/inputs"
+)
+
+func logErr(err error) {
+	fmt.Printf("ERROR: %+v\n", err)
+
+	os.Exit(1)
+}
+
+func useSample(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	if args[0] == "sample" {
+		return true
+	}
+
+	return false
+}
+
+func getInput(args []string) (string, error) {
+	fileName := "input.txt"
+	if useSample(args) {
+		fileName = "sample.txt"
+	}
+
+	data, err := inputs.ReadFile(fileName)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Trim(string(data), "\n"), nil
+}
+
+var (
+	showLayout = flag.Bool("layout", false, "print the disk map as blocks before compacting it")
+	logMoves   = flag.Bool("log", false, "print every move made while compacting, with the layout after it")
+	colour     = flag.Bool("colour", false, "colour file IDs, which tells apart IDs of 36 and above")
+)
+
+func main() {
+	args, err := cmdline.Parse(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		logErr(err)
+	}
+
+	input, err := getInput(args)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	moves = moveLog{layoutRenderer{*colour}, *logMoves}
+
+	if *showLayout {
+		files, err := getFiles(input)
+		if err != nil {
+			logErr(err)
+		}
+
+		fmt.Println(moves.files(files))
+	}
+
+	p1, err := part1(input)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	fmt.Printf("Part 1: %d\n", p1)
+
+	p2, err := part2(input)
+
+	if err != nil {
+		logErr(err)
+	}
+
+	fmt.Printf("Part 2: %d\n", p2)
+}
+
+type File struct {
+	start, id, size int
+	isFree          bool
+}
+
+func getFiles(input string) ([]File, error) {
+	fileId := 0
+	files := make([]File, 0)
+	totalIndex := 0
+
+	for idx, char := range strings.Split(input, "") {
+		size, err := strconv.Atoi(char)
+		if err != nil {
+			return nil, err
+		}
+
+		if idx%2 != 0 {
+			files = append(files, File{
+				id:     0,
+				start:  totalIndex,
+				size:   size,
+				isFree: true,
+			})
+			totalIndex += size
+			continue
+		}
+
+		files = append(files, File{
+			id:     fileId,
+			start:  totalIndex,
+			size:   size,
+			isFree: false,
+		})
+		totalIndex += size
+
+		fileId++
+	}
+
+	return files, nil
+}
+
+func (f *File) toIntArray() []int {
+	result := make([]int, f.size)
+
+	for idx := 0; idx < f.size; idx++ {
+		if f.isFree {
+			result[idx] = -1
+		} else {
+			result[idx] = f.id
+		}
+	}
+
+	return result
+}
+
+// compact streams the disk map from both ends at once, filling each gap on
+// the left with blocks taken from the rightmost file, and returns the checksum.
+// Nothing is materialised, so it runs in constant memory. onMove, if set, is
+// called for each run of blocks moved.
+func compact(diskMap string, onMove func(id, count, from, to int)) (int, error) {
+	total := 0
+
+	for idx := 0; idx < len(diskMap); idx++ {
+		if diskMap[idx] < '0' || diskMap[idx] > '9' {
+			return -1, fmt.Errorf("invalid disk map digit %q at %d", diskMap[idx], idx)
+		}
+
+		total += int(diskMap[idx] - '0')
+	}
+
+	// The digits are read straight from the map, which has been checked above
+	digit := func(idx int) int {
+		return int(diskMap[idx] - '0')
+	}
+
+	if len(diskMap) == 0 {
+		return 0, nil
+	}
+
+	// right is the last file not yet fully moved, with remaining blocks left in
+	// it starting at rightStart.
+	right := len(diskMap) - 1
+	if right%2 != 0 {
+		right--
+	}
+
+	rightStart := total
+	for idx := right; idx < len(diskMap); idx++ {
+		rightStart -= digit(idx)
+	}
+
+	remaining := digit(right)
+
+	checksum := 0
+	pos := 0
+
+	// Adds the checksum of count blocks of id, starting from pos
+	place := func(id, count int) {
+		checksum += id * (count*pos + count*(count-1)/2)
+		pos += count
+	}
+
+	for left := 0; left <= right; left++ {
+		if left == right {
+			place(right/2, remaining)
+			break
+		}
+
+		if left%2 == 0 {
+			place(left/2, digit(left))
+			continue
+		}
+
+		free := digit(left)
+
+		for free > 0 && left < right {
+			count := min(free, remaining)
+
+			if onMove != nil && count > 0 {
+				onMove(right/2, count, rightStart+remaining-count, pos)
+			}
+
+			place(right/2, count)
+			free -= count
+			remaining -= count
+
+			if remaining == 0 {
+				right -= 2
+				rightStart -= digit(right+1) + digit(right)
+				remaining = digit(right)
+			}
+		}
+	}
+
+	return checksum, nil
+}
+
+func part1(input string) (int, error) {
+	if !moves.enabled {
+		return compact(input, nil)
+	}
+
+	files, err := getFiles(input)
+	if err != nil {
+		return -1, err
+	}
+
+	// Only when logging is the disk laid out in memory, so each move can be shown
+	mem := newMemoryManager(files)
+	moves.start("Part 1, moving single blocks:", moves.memory(mem.memory))
+
+	return compact(input, func(id, count, from, to int) {
+		for idx := 0; idx < count; idx++ {
+			mem.memory[from+idx] = -1
+			mem.memory[to+idx] = id
+		}
+
+		moves.move(id, count, from, to, func() string {
+			return moves.memory(mem.memory)
+		})
+	})
+}
+
+type Space struct {
+	start, size int
+}
+
+type MemoryManager struct {
+	memory []int
+}
+
+func newMemoryManager(files []File) MemoryManager {
+	memory := make([]int, 0)
+	totalIndex := 0
+
+	for _, file := range files {
+		memory = append(memory, file.toIntArray()...)
+		totalIndex += file.size
+	}
+
+	return MemoryManager{
+		memory: memory,
+	}
+}
+
+func (m *MemoryManager) insert(f *File, space *Space) {
+	for idx := space.start; idx < space.start+f.size; idx++ {
+		m.memory[idx] = f.id
+	}
+
+	for idx := f.start; idx < f.start+f.size; idx++ {
+		m.memory[idx] = -1
+	}
+}
+
+// 8515929533392 -> High
+func part2(input string) (int, error) {
+	result := 0
+
+	files, err := getFiles(input)
+	if err != nil {
+		return -1, err
+	}
+
+	mem := newMemoryManager(files)
+	spaces := newSpanIndex(files)
+	moves.start("Part 2, moving whole files:", moves.memory(mem.memory))
+
+	for idx := len(files) - 1; idx >= 0; idx-- {
+		file := &files[idx]
+		if file.isFree || file.size == 0 {
+			continue
+		}
+
+		space := spaces.take(file.size, file.start)
+		if space == nil {
+			continue
+		}
+
+		mem.insert(file, space)
+		moves.move(file.id, file.size, file.start, space.start, func() string {
+			return moves.memory(mem.memory)
+		})
+		file.start = space.start
+	}
+
+	for idx, value := range mem.memory {
+		if value >= 0 {
+			result += value * idx
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import "container/heap"
+
+// Disk map digits only go up to 9, so no span is ever bigger than this
+const maxSpanSize = 9
+
+// spanHeap is a min-heap of the start positions of free spans
+type spanHeap []int
+
+func (h spanHeap) Len() int           { return len(h) }
+func (h spanHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h spanHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *spanHeap) Push(x any) {
+	*h = append(*h, x.(int))
+}
+
+func (h *spanHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+
+	return x
+}
+
+// spanIndex keeps one heap of free spans per size, so the leftmost span that
+// fits a file is the smallest top across the heaps for that size and above.
+type spanIndex [maxSpanSize + 1]spanHeap
+
+func newSpanIndex(files []File) *spanIndex {
+	index := &spanIndex{}
+
+	for _, file := range files {
+		if file.isFree && file.size > 0 {
+			index[file.size] = append(index[file.size], file.start)
+		}
+	}
+
+	for size := range index {
+		heap.Init(&index[size])
+	}
+
+	return index
+}
+
+// take removes and returns the leftmost span which can hold size blocks and
+// starts before limit. Any leftover space is put back as a smaller span.
+func (s *spanIndex) take(size, limit int) *Space {
+	best := -1
+
+	for spanSize := size; spanSize <= maxSpanSize; spanSize++ {
+		h := s[spanSize]
+		if len(h) == 0 || h[0] >= limit {
+			continue
+		}
+
+		if best == -1 || h[0] < s[best][0] {
+			best = spanSize
+		}
+	}
+
+	if best == -1 {
+		return nil
+	}
+
+	start := heap.Pop(&s[best]).(int)
+
+	if rest := best - size; rest > 0 {
+		heap.Push(&s[rest], start+size)
+	}
+
+	return &Space{
+		start: start,
+		size:  best,
+	}
+}